@@ -0,0 +1,293 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ AgentPools = (*agentPools)(nil)
+
+// AgentPools describes all the agent pool related methods that the Terraform
+// Enterprise API supports.
+//
+// TFE API docs: https://www.terraform.io/docs/cloud/api/agents.html
+type AgentPools interface {
+	// List all the agent pools of the given organization.
+	List(ctx context.Context, organization string, options AgentPoolListOptions) (*AgentPoolList, error)
+
+	// Create a new agent pool with the given options.
+	Create(ctx context.Context, organization string, options AgentPoolCreateOptions) (*AgentPool, error)
+
+	// Read an agent pool by its ID.
+	Read(ctx context.Context, agentPoolID string) (*AgentPool, error)
+
+	// Delete an agent pool by its ID.
+	Delete(ctx context.Context, agentPoolID string) error
+
+	// Workspaces returns the workspaces that use the given agent pool.
+	Workspaces(ctx context.Context, agentPoolID string) ([]*Workspace, error)
+}
+
+// agentPools implements AgentPools.
+type agentPools struct {
+	client *Client
+}
+
+// AgentStatus represents the status of an agent.
+type AgentStatus string
+
+// List all available agent statuses.
+const (
+	AgentBusy    AgentStatus = "busy"
+	AgentErrored AgentStatus = "errored"
+	AgentExited  AgentStatus = "exited"
+	AgentIdle    AgentStatus = "idle"
+	AgentUnknown AgentStatus = "unknown"
+)
+
+// AgentPoolList represents a list of agent pools.
+type AgentPoolList struct {
+	*Pagination
+	Items []*AgentPool
+}
+
+// AgentPool represents a Terraform Enterprise agent pool.
+type AgentPool struct {
+	ID         string    `jsonapi:"primary,agent-pools"`
+	AgentCount int       `jsonapi:"attr,agent-count"`
+	CreatedAt  time.Time `jsonapi:"attr,created-at,iso8601"`
+	Name       string    `jsonapi:"attr,name"`
+
+	// The number of agents in the pool that are idle or busy. This is only
+	// set when listing agent pools with AgentPoolListOptions.OnlineAgentCounts
+	// enabled.
+	OnlineAgentCount int
+
+	// Relations
+	Organization *Organization `jsonapi:"relation,organization"`
+	Workspaces   []*Workspace  `jsonapi:"relation,workspaces"`
+}
+
+// Agent represents a Terraform Enterprise agent.
+type Agent struct {
+	ID         string      `jsonapi:"primary,agents"`
+	IP         string      `jsonapi:"attr,ip-address"`
+	LastPingAt time.Time   `jsonapi:"attr,last-ping-at,iso8601"`
+	Name       string      `jsonapi:"attr,name"`
+	Status     AgentStatus `jsonapi:"attr,status"`
+}
+
+// agentList represents a list of agents.
+type agentList struct {
+	*Pagination
+	Items []*Agent
+}
+
+// AgentPoolListOptions represents the options for listing agent pools.
+type AgentPoolListOptions struct {
+	ListOptions
+
+	// Count the online agents of each listed agent pool. The API does not
+	// report these counts, so the agents of every listed pool are listed
+	// to count them, with the pools processed concurrently.
+	OnlineAgentCounts bool `url:"-"`
+}
+
+// List all the agent pools of the given organization.
+func (s *agentPools) List(ctx context.Context, organization string, options AgentPoolListOptions) (*AgentPoolList, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+
+	u := fmt.Sprintf("organizations/%s/agent-pools", url.PathEscape(organization))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	pl := &AgentPoolList{}
+	err = s.client.do(ctx, req, pl)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+	parseErr := err
+
+	if options.OnlineAgentCounts {
+		pools := make(map[string]*AgentPool, len(pl.Items))
+		ids := make([]string, len(pl.Items))
+		for i, p := range pl.Items {
+			pools[p.ID] = p
+			ids[i] = p.ID
+		}
+
+		err := forEach(ctx, ids, func(ctx context.Context, id string) error {
+			online, err := s.countOnlineAgents(ctx, id)
+			if err != nil {
+				return err
+			}
+			pools[id].OnlineAgentCount = online
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pl, parseErr
+}
+
+// countOnlineAgents returns the number of idle or busy agents in a pool.
+func (s *agentPools) countOnlineAgents(ctx context.Context, agentPoolID string) (int, error) {
+	u := fmt.Sprintf("agent-pools/%s/agents", url.PathEscape(agentPoolID))
+
+	online := 0
+	options := ListOptions{}
+	for {
+		req, err := s.client.newRequest("GET", u, &options)
+		if err != nil {
+			return 0, err
+		}
+
+		al := &agentList{}
+		err = s.client.do(ctx, req, al)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, a := range al.Items {
+			if a.Status == AgentIdle || a.Status == AgentBusy {
+				online++
+			}
+		}
+
+		if al.Pagination == nil || al.NextPage == 0 {
+			break
+		}
+		options.PageNumber = al.NextPage
+	}
+
+	return online, nil
+}
+
+// AgentPoolCreateOptions represents the options for creating an agent pool.
+type AgentPoolCreateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,agent-pools"`
+
+	// The name of the agent pool.
+	Name *string `jsonapi:"attr,name"`
+}
+
+func (o AgentPoolCreateOptions) valid() error {
+	if !validString(o.Name) {
+		return errors.New("name is required")
+	}
+	if !validStringID(o.Name) {
+		return errors.New("invalid value for name")
+	}
+	return nil
+}
+
+// Create a new agent pool with the given options.
+func (s *agentPools) Create(ctx context.Context, organization string, options AgentPoolCreateOptions) (*AgentPool, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("organizations/%s/agent-pools", url.PathEscape(organization))
+	req, err := s.client.newRequest("POST", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &AgentPool{}
+	err = s.client.do(ctx, req, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Read an agent pool by its ID.
+func (s *agentPools) Read(ctx context.Context, agentPoolID string) (*AgentPool, error) {
+	if !validStringID(&agentPoolID) {
+		return nil, errors.New("invalid value for agent pool ID")
+	}
+
+	u := fmt.Sprintf("agent-pools/%s", url.PathEscape(agentPoolID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &AgentPool{}
+	err = s.client.do(ctx, req, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Delete an agent pool by its ID.
+func (s *agentPools) Delete(ctx context.Context, agentPoolID string) error {
+	if !validStringID(&agentPoolID) {
+		return errors.New("invalid value for agent pool ID")
+	}
+
+	u := fmt.Sprintf("agent-pools/%s", url.PathEscape(agentPoolID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// Workspaces returns the workspaces that use the given agent pool. The agent
+// pool only references the workspaces by ID, so the workspaces of the
+// organization are listed to return the complete workspaces.
+func (s *agentPools) Workspaces(ctx context.Context, agentPoolID string) ([]*Workspace, error) {
+	p, err := s.Read(ctx, agentPoolID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*Workspace{}
+	if len(p.Workspaces) == 0 {
+		return result, nil
+	}
+
+	if p.Organization == nil {
+		return nil, fmt.Errorf("agent pool %s does not have an organization", agentPoolID)
+	}
+
+	ids := make(map[string]bool, len(p.Workspaces))
+	for _, w := range p.Workspaces {
+		ids[w.ID] = true
+	}
+
+	workspaces, err := s.client.listAllWorkspaces(ctx, p.Organization.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, w := range workspaces {
+		if ids[w.ID] {
+			result = append(result, w)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,213 @@
+package tfe
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentPoolsList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	pTest, pTestCleanup := createAgentPool(t, client, orgTest)
+	defer pTestCleanup()
+
+	t.Run("without list options", func(t *testing.T) {
+		pl, err := client.AgentPools.List(ctx, orgTest.Name, AgentPoolListOptions{})
+		require.NoError(t, err)
+		require.Len(t, pl.Items, 1)
+
+		assert.Equal(t, pTest.ID, pl.Items[0].ID)
+	})
+
+	t.Run("with online agent counts", func(t *testing.T) {
+		pl, err := client.AgentPools.List(ctx, orgTest.Name, AgentPoolListOptions{
+			OnlineAgentCounts: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, pl.Items, 1)
+
+		assert.Equal(t, pTest.ID, pl.Items[0].ID)
+		assert.Equal(t, 0, pl.Items[0].OnlineAgentCount)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		pl, err := client.AgentPools.List(ctx, badIdentifier, AgentPoolListOptions{})
+		assert.Nil(t, pl)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestAgentPoolsListOnlineAgentCounts(t *testing.T) {
+	var agentRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+
+		switch r.URL.Path {
+		case "/api/v2/organizations/org-test/agent-pools":
+			w.Write([]byte(`{"data": [
+				{"type": "agent-pools", "id": "apool-1"},
+				{"type": "agent-pools", "id": "apool-2"}
+			]}`))
+		case "/api/v2/agent-pools/apool-1/agents":
+			atomic.AddInt32(&agentRequests, 1)
+			w.Write([]byte(`{"data": [
+				{"type": "agents", "id": "agent-1", "attributes": {"status": "idle"}},
+				{"type": "agents", "id": "agent-2", "attributes": {"status": "busy"}},
+				{"type": "agents", "id": "agent-3", "attributes": {"status": "exited"}}
+			]}`))
+		case "/api/v2/agent-pools/apool-2/agents":
+			atomic.AddInt32(&agentRequests, 1)
+			w.Write([]byte(`{"data": [
+				{"type": "agents", "id": "agent-4", "attributes": {"status": "errored"}}
+			]}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("without online agent counts", func(t *testing.T) {
+		pl, err := client.AgentPools.List(ctx, "org-test", AgentPoolListOptions{})
+		require.NoError(t, err)
+		require.Len(t, pl.Items, 2)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&agentRequests))
+	})
+
+	t.Run("with online agent counts", func(t *testing.T) {
+		pl, err := client.AgentPools.List(ctx, "org-test", AgentPoolListOptions{
+			OnlineAgentCounts: true,
+		})
+		require.NoError(t, err)
+		require.Len(t, pl.Items, 2)
+		assert.Equal(t, 2, pl.Items[0].OnlineAgentCount)
+		assert.Equal(t, 0, pl.Items[1].OnlineAgentCount)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&agentRequests))
+	})
+}
+
+func TestAgentPoolsCreate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	t.Run("with valid options", func(t *testing.T) {
+		options := AgentPoolCreateOptions{
+			Name: String(randomString(t)),
+		}
+
+		p, err := client.AgentPools.Create(ctx, orgTest.Name, options)
+		require.NoError(t, err)
+		defer client.AgentPools.Delete(ctx, p.ID)
+
+		assert.Equal(t, *options.Name, p.Name)
+	})
+
+	t.Run("when options is missing name", func(t *testing.T) {
+		p, err := client.AgentPools.Create(ctx, orgTest.Name, AgentPoolCreateOptions{})
+		assert.Nil(t, p)
+		assert.EqualError(t, err, "name is required")
+	})
+
+	t.Run("with an invalid organization", func(t *testing.T) {
+		p, err := client.AgentPools.Create(ctx, badIdentifier, AgentPoolCreateOptions{
+			Name: String(randomString(t)),
+		})
+		assert.Nil(t, p)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestAgentPoolsRead(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	pTest, pTestCleanup := createAgentPool(t, client, nil)
+	defer pTestCleanup()
+
+	t.Run("when the agent pool exists", func(t *testing.T) {
+		p, err := client.AgentPools.Read(ctx, pTest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, pTest, p)
+	})
+
+	t.Run("when the agent pool does not exist", func(t *testing.T) {
+		p, err := client.AgentPools.Read(ctx, "nonexisting")
+		assert.Nil(t, p)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid agent pool ID", func(t *testing.T) {
+		p, err := client.AgentPools.Read(ctx, badIdentifier)
+		assert.Nil(t, p)
+		assert.EqualError(t, err, "invalid value for agent pool ID")
+	})
+}
+
+func TestAgentPoolsDelete(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	pTest, _ := createAgentPool(t, client, orgTest)
+
+	t.Run("with valid options", func(t *testing.T) {
+		err := client.AgentPools.Delete(ctx, pTest.ID)
+		require.NoError(t, err)
+
+		_, err = client.AgentPools.Read(ctx, pTest.ID)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid agent pool ID", func(t *testing.T) {
+		err := client.AgentPools.Delete(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for agent pool ID")
+	})
+}
+
+func TestAgentPoolsWorkspaces(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	pTest, pTestCleanup := createAgentPool(t, client, nil)
+	defer pTestCleanup()
+
+	t.Run("without any workspaces", func(t *testing.T) {
+		ws, err := client.AgentPools.Workspaces(ctx, pTest.ID)
+		require.NoError(t, err)
+		assert.Empty(t, ws)
+	})
+
+	t.Run("without a valid agent pool ID", func(t *testing.T) {
+		ws, err := client.AgentPools.Workspaces(ctx, badIdentifier)
+		assert.Nil(t, ws)
+		assert.EqualError(t, err, "invalid value for agent pool ID")
+	})
+}
@@ -72,7 +72,7 @@ func (s *applies) Read(ctx context.Context, applyID string) (*Apply, error) {
 		return nil, errors.New("invalid value for apply ID")
 	}
 
-	u := fmt.Sprintf("applies/%s", url.QueryEscape(applyID))
+	u := fmt.Sprintf("applies/%s", url.PathEscape(applyID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
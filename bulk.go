@@ -0,0 +1,213 @@
+package tfe
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// bulkConcurrency is the maximum number of operations the bulk helpers
+// will run concurrently. Every request still passes the rate limiter.
+const bulkConcurrency = 10
+
+// BulkError is returned by the bulk helpers when one or more of the
+// individual operations failed. The errors are keyed by the ID of the
+// resource the failed operation was performed on. Use RetryFailed to
+// retry only the failed operations.
+type BulkError struct {
+	Errors map[string]error
+
+	// The failed operations, ordered by their index.
+	Failures []*BulkFailure
+
+	// Retries the failed operations.
+	retry bulkRetry
+}
+
+// bulkRetry retries the operations of a bulk helper for the given IDs, where
+// indexes holds the index of each ID in the IDs of the original call. It
+// returns a new result holding only the retried operations.
+type bulkRetry func(ctx context.Context, indexes []int, ids []string) (interface{}, error)
+
+// BulkFailure describes a single failed operation of a bulk helper.
+type BulkFailure struct {
+	// The index of the ID in the IDs originally passed to the bulk helper.
+	Index int
+
+	// The ID of the resource the operation was performed on.
+	ID string
+
+	// The options the operation was attempted with, for example the
+	// WorkspaceUpdateOptions used by Workspaces.SetAssessmentsEnabled or
+	// the tag names used by Workspaces.AddTags, or nil if the bulk helper
+	// does not take any options.
+	Options interface{}
+
+	// The error returned by the operation.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BulkError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []string
+	for _, id := range ids {
+		errs = append(errs, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+
+	return fmt.Sprintf("%d operation(s) failed:\n\n%s", len(errs), strings.Join(errs, "\n"))
+}
+
+// RetryFailed retries the failed operations of the bulk helper that
+// returned err, without redoing the operations that succeeded. The options
+// used by the original call are reused for the retries.
+//
+// The result of the retried operations is returned as a new value of the
+// type returned by the bulk helper, for example a map[string]*Run for
+// Workspaces.CurrentRuns, holding only the retried operations. The result
+// of the original call is left untouched. For bulk helpers that only return
+// an error, the result is nil.
+//
+// The error is nil if all retried operations succeed, or a new *BulkError
+// describing the operations that failed again, with the indexes of the
+// original call. Any error that is not a *BulkError is returned unchanged.
+func RetryFailed(ctx context.Context, err error) (interface{}, error) {
+	bulkErr, ok := err.(*BulkError)
+	if !ok || bulkErr.retry == nil {
+		return nil, err
+	}
+
+	indexes := make([]int, len(bulkErr.Failures))
+	ids := make([]string, len(bulkErr.Failures))
+	for i, f := range bulkErr.Failures {
+		indexes[i], ids[i] = f.Index, f.ID
+	}
+
+	return bulkErr.retry(ctx, indexes, ids)
+}
+
+// withRetry makes RetryFailed use retry to retry the failed operations of
+// err, if err is a *BulkError. It is used by bulk helpers that return a
+// result, so that retrying builds a new result instead of modifying the
+// result of the original call.
+func withRetry(err error, retry bulkRetry) error {
+	if bulkErr, ok := err.(*BulkError); ok {
+		bulkErr.retry = retry
+	}
+	return err
+}
+
+// destructiveConfirmedKey is the context key used to confirm destructive
+// bulk operations.
+type destructiveConfirmedKey struct{}
+
+// WithDestructiveConfirmed returns a copy of ctx that confirms destructive
+// bulk operations when Config.ConfirmDestructive is enabled.
+func WithDestructiveConfirmed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, destructiveConfirmedKey{}, true)
+}
+
+// checkDestructive returns ErrDestructiveNotConfirmed if destructive bulk
+// operations must be confirmed, and ctx does not confirm them.
+func (c *Client) checkDestructive(ctx context.Context) error {
+	if !c.confirmDestructive {
+		return nil
+	}
+	if confirmed, _ := ctx.Value(destructiveConfirmedKey{}).(bool); !confirmed {
+		return ErrDestructiveNotConfirmed
+	}
+	return nil
+}
+
+// forEach calls fn for each of the given IDs, running at most
+// bulkConcurrency calls concurrently. It waits for all calls to finish
+// and returns a *BulkError describing the failed calls, if any.
+func forEach(ctx context.Context, ids []string, fn func(ctx context.Context, id string) error) error {
+	return forEachIndexed(ctx, bulkIndexes(len(ids)), ids, nil, fn)
+}
+
+// forEachWithOptions is like forEach, but records the options every
+// operation is performed with in the failures.
+func forEachWithOptions(ctx context.Context, ids []string, options interface{}, fn func(ctx context.Context, id string) error) error {
+	return forEachIndexed(ctx, bulkIndexes(len(ids)), ids, options, fn)
+}
+
+// forEachIndexed calls fn for each of the given IDs, where indexes holds
+// the index of each ID in the IDs of the original call.
+func forEachIndexed(ctx context.Context, indexes []int, ids []string, options interface{}, fn func(ctx context.Context, id string) error) error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	var failures []*BulkFailure
+	sem := make(chan struct{}, bulkConcurrency)
+
+	fail := func(i int, err error) {
+		mu.Lock()
+		failures = append(failures, &BulkFailure{Index: indexes[i], ID: ids[i], Options: options, Err: err})
+		mu.Unlock()
+	}
+
+	for i, id := range ids {
+		// Wait for a free slot, but don't start any new
+		// calls once the context is done.
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			fail(i, err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			if err := fn(ctx, id); err != nil {
+				fail(i, err)
+			}
+		}(i, id)
+	}
+
+	wg.Wait()
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Index < failures[j].Index
+	})
+
+	errs := make(map[string]error, len(failures))
+	for _, f := range failures {
+		errs[f.ID] = f.Err
+	}
+
+	return &BulkError{
+		Errors:   errs,
+		Failures: failures,
+		retry: func(ctx context.Context, indexes []int, ids []string) (interface{}, error) {
+			return nil, forEachIndexed(ctx, indexes, ids, options, fn)
+		},
+	}
+}
+
+// bulkIndexes returns the indexes of n IDs passed to a bulk helper.
+func bulkIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
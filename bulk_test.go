@@ -0,0 +1,274 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEach(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("calls fn for every ID", func(t *testing.T) {
+		var calls int32
+		err := forEach(ctx, []string{"a", "b", "c"}, func(ctx context.Context, id string) error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), calls)
+	})
+
+	t.Run("limits the number of concurrent calls", func(t *testing.T) {
+		var running, max int32
+
+		var ids []string
+		for i := 0; i < 3*bulkConcurrency; i++ {
+			ids = append(ids, randomString(t))
+		}
+
+		err := forEach(ctx, ids, func(ctx context.Context, id string) error {
+			n := atomic.AddInt32(&running, 1)
+			defer atomic.AddInt32(&running, -1)
+
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.True(t, max <= bulkConcurrency, "expected at most %d concurrent calls, got %d", bulkConcurrency, max)
+	})
+
+	t.Run("collects all errors", func(t *testing.T) {
+		err := forEach(ctx, []string{"a", "b", "c"}, func(ctx context.Context, id string) error {
+			if id == "b" {
+				return nil
+			}
+			return errors.New("failed " + id)
+		})
+
+		bulkErr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got %T", err)
+		assert.Len(t, bulkErr.Errors, 2)
+		assert.EqualError(t, bulkErr.Errors["a"], "failed a")
+		assert.EqualError(t, bulkErr.Errors["c"], "failed c")
+		assert.EqualError(t, err, "2 operation(s) failed:\n\na: failed a\nc: failed c")
+	})
+
+	t.Run("with a canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		err := forEach(ctx, []string{"a"}, func(ctx context.Context, id string) error {
+			t.Fatal("expected fn not to be called")
+			return nil
+		})
+
+		bulkErr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got %T", err)
+		assert.Equal(t, context.Canceled, bulkErr.Errors["a"])
+	})
+}
+
+func TestRetryFailed(t *testing.T) {
+	ctx := context.Background()
+
+	attempts := make(map[string]int)
+	var mu sync.Mutex
+
+	// Item "b" fails twice and "d" fails once.
+	fn := func(ctx context.Context, id string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts[id]++
+		if (id == "b" && attempts[id] <= 2) || (id == "d" && attempts[id] == 1) {
+			return errors.New("failed " + id)
+		}
+		return nil
+	}
+
+	err := forEach(ctx, []string{"a", "b", "c", "d"}, fn)
+
+	bulkErr, ok := err.(*BulkError)
+	require.True(t, ok, "expected a *BulkError, got %T", err)
+	require.Len(t, bulkErr.Failures, 2)
+	assert.Equal(t, 1, bulkErr.Failures[0].Index)
+	assert.Equal(t, "b", bulkErr.Failures[0].ID)
+	assert.EqualError(t, bulkErr.Failures[0].Err, "failed b")
+	assert.Equal(t, 3, bulkErr.Failures[1].Index)
+	assert.Equal(t, "d", bulkErr.Failures[1].ID)
+
+	t.Run("retries only the failed items", func(t *testing.T) {
+		var result interface{}
+		result, err = RetryFailed(ctx, err)
+		assert.Nil(t, result)
+
+		bulkErr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got %T", err)
+		require.Len(t, bulkErr.Failures, 1)
+		assert.Equal(t, 1, bulkErr.Failures[0].Index)
+		assert.Equal(t, "b", bulkErr.Failures[0].ID)
+
+		assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 1, "d": 2}, attempts)
+	})
+
+	t.Run("when all retries succeed", func(t *testing.T) {
+		_, err = RetryFailed(ctx, err)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "b": 3, "c": 1, "d": 2}, attempts)
+	})
+
+	t.Run("with other errors", func(t *testing.T) {
+		result, err := RetryFailed(ctx, nil)
+		assert.Nil(t, result)
+		assert.NoError(t, err)
+
+		other := errors.New("other")
+		result, err = RetryFailed(ctx, other)
+		assert.Nil(t, result)
+		assert.Equal(t, other, err)
+	})
+}
+
+func TestRetryFailedResults(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+
+	// Reading workspace ws-2 fails the first time, and updating
+	// workspace ws-3 always fails.
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/workspaces/")
+
+		mu.Lock()
+		attempts[r.Method+" "+id]++
+		n := attempts[r.Method+" "+id]
+		mu.Unlock()
+
+		if (r.Method == "GET" && id == "ws-2" && n == 1) || (r.Method == "PATCH" && id == "ws-3") {
+			w.WriteHeader(500)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprintf(w, `{
+			"data": {"type": "workspaces", "id": %q, "relationships": {
+				"current-run": {"data": {"type": "runs", "id": "run-%s"}}
+			}},
+			"included": [{"type": "runs", "id": "run-%s", "attributes": {"status": "applied"}}]
+		}`, id, id, id)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("returns a new result", func(t *testing.T) {
+		runs, err := client.Workspaces.CurrentRuns(ctx, []string{"ws-1", "ws-2"})
+		require.Error(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, "run-ws-1", runs["ws-1"].ID)
+		assert.Nil(t, runs["ws-2"])
+
+		result, err := RetryFailed(ctx, err)
+		require.NoError(t, err)
+
+		retried, ok := result.(map[string]*Run)
+		require.True(t, ok, "expected a map[string]*Run, got %T", result)
+		require.Len(t, retried, 1)
+		assert.Equal(t, "run-ws-2", retried["ws-2"].ID)
+
+		// The result of the original call is left untouched.
+		assert.Nil(t, runs["ws-2"])
+	})
+
+	t.Run("records the attempted options", func(t *testing.T) {
+		err := client.Workspaces.SetAssessmentsEnabled(ctx, []string{"ws-1", "ws-3"}, true)
+
+		bulkErr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got %T", err)
+		require.Len(t, bulkErr.Failures, 1)
+		assert.Equal(t, 1, bulkErr.Failures[0].Index)
+		assert.Equal(t, "ws-3", bulkErr.Failures[0].ID)
+		assert.Equal(t, WorkspaceUpdateOptions{AssessmentsEnabled: Bool(true)}, bulkErr.Failures[0].Options)
+	})
+}
+
+func TestCheckDestructive(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("when confirmation is not required", func(t *testing.T) {
+		c := &Client{}
+		assert.NoError(t, c.checkDestructive(ctx))
+	})
+
+	t.Run("when confirmation is required", func(t *testing.T) {
+		c := &Client{confirmDestructive: true}
+		assert.Equal(t, ErrDestructiveNotConfirmed, c.checkDestructive(ctx))
+		assert.NoError(t, c.checkDestructive(WithDestructiveConfirmed(ctx)))
+	})
+
+	t.Run("when a bulk helper is not confirmed", func(t *testing.T) {
+		c := &Client{confirmDestructive: true}
+		c.Workspaces = &workspaces{client: c}
+
+		err := c.Workspaces.RemoveTags(ctx, []string{"ws-123"}, []string{"foo"})
+		assert.Equal(t, ErrDestructiveNotConfirmed, err)
+
+		ids, err := c.Workspaces.CancelPendingRuns(ctx, "ws-123", "")
+		assert.Nil(t, ids)
+		assert.Equal(t, ErrDestructiveNotConfirmed, err)
+	})
+
+	t.Run("when a single workspace is deleted", func(t *testing.T) {
+		var deleted string
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "30")
+			if r.Method == "DELETE" {
+				deleted = r.URL.Path
+			}
+			w.WriteHeader(204)
+		}))
+		defer ts.Close()
+
+		c, err := NewClient(&Config{
+			Address:            ts.URL,
+			Token:              "dummy-token",
+			HTTPClient:         ts.Client(),
+			ConfirmDestructive: true,
+		})
+		require.NoError(t, err)
+
+		err = c.Workspaces.SafeDelete(ctx, "ws-123", WorkspaceSafeDeleteOptions{Force: true})
+		require.NoError(t, err)
+		assert.Equal(t, "/api/v2/workspaces/ws-123", deleted)
+	})
+}
@@ -0,0 +1,262 @@
+package tfe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// redacted replaces sensitive values in captured requests.
+const redacted = "REDACTED"
+
+// sensitiveAttributes lists the request attributes that are always redacted
+// in captured requests. Values are redacted whether or not the variable is
+// marked as sensitive in the same request, as updates of sensitive
+// variables usually only send the new value.
+var sensitiveAttributes = map[string]bool{
+	"oauth-token-string": true,
+	"password":           true,
+	"private-key":        true,
+	"secret":             true,
+	"ssh-key":            true,
+	"token":              true,
+	"value":              true,
+}
+
+// safeHeaders lists the request headers that are captured as-is. All other
+// headers, like the Authorization header, signature headers added by a
+// request signer and custom headers, are redacted.
+var safeHeaders = map[string]bool{
+	"Accept":            true,
+	"Content-Type":      true,
+	"User-Agent":        true,
+	CorrelationIDHeader: true,
+}
+
+// CapturedRequest is a serialized copy of a failed request, captured when
+// Config.CaptureFailedRequests is enabled. All headers except a few safe
+// ones and all sensitive values in the body are redacted. Use Client.Replay
+// to send the request again.
+type CapturedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// CapturedRequestError wraps the error returned for a failed request when
+// Config.CaptureFailedRequests is enabled. Use errors.As to retrieve the
+// captured request, and errors.Is or errors.As to inspect the wrapped error.
+type CapturedRequestError struct {
+	// The captured request.
+	Request *CapturedRequest
+
+	// The error returned for the request.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *CapturedRequestError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *CapturedRequestError) Unwrap() error {
+	return e.Err
+}
+
+// withCapturedRequest wraps err in a *CapturedRequestError when capturing
+// failed requests is enabled, and returns err unchanged otherwise.
+func (c *Client) withCapturedRequest(req *http.Request, err error) error {
+	if !c.captureFailedRequests {
+		return err
+	}
+	return &CapturedRequestError{Request: captureRequest(req), Err: err}
+}
+
+// captureRequest serializes the given request, redacting all headers that
+// are not known to be safe and any sensitive values in a JSON body. Bodies
+// that are not JSON, like uploaded archives, are not captured.
+func captureRequest(req *http.Request) *CapturedRequest {
+	captured := &CapturedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: make(http.Header, len(req.Header)),
+	}
+
+	for k, v := range req.Header {
+		if safeHeaders[http.CanonicalHeaderKey(k)] {
+			captured.Header[k] = append([]string(nil), v...)
+		} else {
+			captured.Header[k] = []string{redacted}
+		}
+	}
+
+	if req.GetBody == nil || !strings.Contains(req.Header.Get("Content-Type"), "json") {
+		return captured
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return captured
+	}
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return captured
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return captured
+	}
+
+	data, err = json.Marshal(redactValues(doc))
+	if err != nil {
+		return captured
+	}
+	captured.Body = string(data)
+
+	return captured
+}
+
+// redactValues redacts the sensitive attributes of a decoded JSON document.
+func redactValues(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if sensitiveAttributes[k] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactValues(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValues(value)
+		}
+	}
+	return v
+}
+
+// ReplayOptions represents the options for replaying a captured request.
+type ReplayOptions struct {
+	// Replay a request that modifies resources even though its body
+	// contains redacted values. The redacted values are sent as the
+	// literal "REDACTED", which overwrites the original values.
+	AllowRedacted bool
+}
+
+// Replay sends a captured request again, logging the full request and
+// response using the configured logger, or the standard logger if none is
+// configured. The request is authenticated with the token of the client,
+// uses the default headers of the client and the correlation ID of ctx, and
+// passes the rate limiter, the retry policy and the retry hook like any
+// other request.
+//
+// Requests other than GET requests whose body contains redacted values are
+// refused, unless ReplayOptions.AllowRedacted is set, so that replaying a
+// failed mutation does not overwrite secrets with the redacted placeholder.
+func (c *Client) Replay(ctx context.Context, captured *CapturedRequest, options ReplayOptions) error {
+	if captured.Method != "GET" && !options.AllowRedacted &&
+		strings.Contains(captured.Body, `"`+redacted+`"`) {
+		return errors.New("captured request contains redacted values")
+	}
+
+	logger := c.logger
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+
+	// Interrupt the request when the client is closed.
+	ctx, cancel := c.closeContext(ctx)
+	defer cancel()
+
+	// Wait will block until the limiter can obtain a new token
+	// or returns an error if the given context is canceled.
+	if err := c.wait(ctx); err != nil {
+		return err
+	}
+
+	var body interface{}
+	if captured.Body != "" {
+		body = bytes.NewBufferString(captured.Body)
+	}
+
+	req, err := retryablehttp.NewRequest(captured.Method, captured.URL, body)
+	if err != nil {
+		return err
+	}
+
+	if captured.Body != "" {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(captured.Body)), nil
+		}
+	}
+
+	// Use the captured headers that were not redacted, and replace the
+	// others with the headers of the client.
+	for k, v := range captured.Header {
+		if len(v) != 1 || v[0] != redacted {
+			req.Header[k] = v
+		}
+	}
+	for k, v := range c.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Set up the request like any other request, so that the retry
+	// hook sees the retry attempts and the correlation ID is sent.
+	req = c.withRequestContext(ctx, req)
+
+	// Sign the request, if needed.
+	if err := c.signRequest(req.Request); err != nil {
+		return err
+	}
+
+	logger.Printf("[DEBUG] go-tfe: replaying request:\n%s %s\n%s\n%s",
+		req.Method, req.URL, formatHeader(captured.Header), captured.Body)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		logger.Printf("[DEBUG] go-tfe: replayed request failed: %v", err)
+		if c.isClosed() {
+			return ErrClientClosed
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	logger.Printf("[DEBUG] go-tfe: replayed request response:\n%s\n%s\n%s",
+		resp.Status, formatHeader(resp.Header), respBody)
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	return checkResponseCode(resp)
+}
+
+// formatHeader formats a header for logging, with the keys sorted.
+func formatHeader(h http.Header) string {
+	buf := bytes.NewBuffer(nil)
+	if err := h.Write(buf); err != nil {
+		return fmt.Sprintf("%v", h)
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -105,7 +105,7 @@ func (s *configurationVersions) List(ctx context.Context, workspaceID string, op
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/configuration-versions", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/configuration-versions", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -113,11 +113,11 @@ func (s *configurationVersions) List(ctx context.Context, workspaceID string, op
 
 	cvl := &ConfigurationVersionList{}
 	err = s.client.do(ctx, req, cvl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return cvl, nil
+	return cvl, err
 }
 
 // ConfigurationVersionCreateOptions represents the options for creating a
@@ -144,7 +144,7 @@ func (s *configurationVersions) Create(ctx context.Context, workspaceID string,
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("workspaces/%s/configuration-versions", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/configuration-versions", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -165,7 +165,7 @@ func (s *configurationVersions) Read(ctx context.Context, cvID string) (*Configu
 		return nil, errors.New("invalid value for configuration version ID")
 	}
 
-	u := fmt.Sprintf("configuration-versions/%s", url.QueryEscape(cvID))
+	u := fmt.Sprintf("configuration-versions/%s", url.PathEscape(cvID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
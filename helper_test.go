@@ -24,6 +24,34 @@ func testClient(t *testing.T) *Client {
 	return client
 }
 
+func createAgentPool(t *testing.T, client *Client, org *Organization) (*AgentPool, func()) {
+	var orgCleanup func()
+
+	if org == nil {
+		org, orgCleanup = createOrganization(t, client)
+	}
+
+	ctx := context.Background()
+	p, err := client.AgentPools.Create(ctx, org.Name, AgentPoolCreateOptions{
+		Name: String(randomString(t)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p, func() {
+		if err := client.AgentPools.Delete(ctx, p.ID); err != nil {
+			t.Errorf("Error destroying agent pool! WARNING: Dangling resources\n"+
+				"may exist! The full error is shown below.\n\n"+
+				"Agent pool: %s\nError: %s", p.ID, err)
+		}
+
+		if orgCleanup != nil {
+			orgCleanup()
+		}
+	}
+}
+
 func createConfigurationVersion(t *testing.T, client *Client, w *Workspace) (*ConfigurationVersion, func()) {
 	var wCleanup func()
 
@@ -176,6 +204,39 @@ func createUploadedPolicy(t *testing.T, client *Client, pass bool, org *Organiza
 	}
 }
 
+func createNotificationConfiguration(t *testing.T, client *Client, w *Workspace) (*NotificationConfiguration, func()) {
+	var wCleanup func()
+
+	if w == nil {
+		w, wCleanup = createWorkspace(t, client, nil)
+	}
+
+	ctx := context.Background()
+	nc, err := client.NotificationConfigurations.Create(ctx, w.ID, NotificationConfigurationCreateOptions{
+		DestinationType: NotificationDestination(NotificationDestinationTypeGeneric),
+		Enabled:         Bool(false),
+		Name:            String(randomString(t)),
+		Token:           String(randomString(t)),
+		URL:             String("http://example.com"),
+		Triggers:        []string{NotificationTriggerCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return nc, func() {
+		if err := client.NotificationConfigurations.Delete(ctx, nc.ID); err != nil {
+			t.Errorf("Error destroying notification configuration! WARNING: Dangling\n"+
+				"resources may exist! The full error is shown below.\n\n"+
+				"NotificationConfiguration: %s\nError: %s", nc.ID, err)
+		}
+
+		if wCleanup != nil {
+			wCleanup()
+		}
+	}
+}
+
 func createOAuthClient(t *testing.T, client *Client, org *Organization) (*OAuthClient, func()) {
 	var orgCleanup func()
 
@@ -549,6 +610,36 @@ func createVariable(t *testing.T, client *Client, w *Workspace) (*Variable, func
 	}
 }
 
+func createVariableSet(t *testing.T, client *Client, org *Organization, options VariableSetCreateOptions) (*VariableSet, func()) {
+	var orgCleanup func()
+
+	if org == nil {
+		org, orgCleanup = createOrganization(t, client)
+	}
+
+	if options.Name == nil {
+		options.Name = String(randomString(t))
+	}
+
+	ctx := context.Background()
+	vs, err := client.VariableSets.Create(ctx, org.Name, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return vs, func() {
+		if err := client.VariableSets.Delete(ctx, vs.ID); err != nil {
+			t.Errorf("Error destroying variable set! WARNING: Dangling resources\n"+
+				"may exist! The full error is shown below.\n\n"+
+				"Variable set: %s\nError: %s", vs.Name, err)
+		}
+
+		if orgCleanup != nil {
+			orgCleanup()
+		}
+	}
+}
+
 func createWorkspace(t *testing.T, client *Client, org *Organization) (*Workspace, func()) {
 	var orgCleanup func()
 
@@ -44,6 +44,8 @@ func (r *LogReader) Read(l []byte) (int, error) {
 		select {
 		case <-r.ctx.Done():
 			return 0, r.ctx.Err()
+		case <-r.client.closed:
+			return 0, ErrClientClosed
 		case <-time.After(backoff(500, 2000, r.reads)):
 			if written, err := r.read(l); err != io.ErrNoProgress {
 				return written, err
@@ -53,6 +55,10 @@ func (r *LogReader) Read(l []byte) (int, error) {
 }
 
 func (r *LogReader) read(l []byte) (int, error) {
+	if r.client.isClosed() {
+		return 0, ErrClientClosed
+	}
+
 	// Update the query string.
 	r.logURL.RawQuery = fmt.Sprintf("limit=%d&offset=%d", len(l), r.offset)
 
@@ -61,16 +67,28 @@ func (r *LogReader) read(l []byte) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	req = req.WithContext(r.ctx)
+
+	// Interrupt the request when the client is closed.
+	ctx, cancel := r.client.closeContext(r.ctx)
+	defer cancel()
+	req = req.WithContext(ctx)
 
 	// Attach the default headers.
 	for k, v := range r.client.headers {
 		req.Header[k] = v
 	}
 
+	// Sign the request, if needed.
+	if err := r.client.signRequest(req); err != nil {
+		return 0, err
+	}
+
 	// Retrieve the next chunk.
 	resp, err := r.client.http.HTTPClient.Do(req)
 	if err != nil {
+		if r.client.isClosed() {
+			return 0, ErrClientClosed
+		}
 		return 0, err
 	}
 	defer resp.Body.Close()
@@ -141,3 +159,21 @@ func (r *LogReader) read(l []byte) (int, error) {
 
 	return written, nil
 }
+
+// lazyReader implements io.Reader for a reader that should only be opened
+// when it is first read from.
+type lazyReader struct {
+	open   func() (io.Reader, error)
+	reader io.Reader
+}
+
+func (r *lazyReader) Read(l []byte) (int, error) {
+	if r.reader == nil {
+		reader, err := r.open()
+		if err != nil {
+			return 0, err
+		}
+		r.reader = reader
+	}
+	return r.reader.Read(l)
+}
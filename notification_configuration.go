@@ -0,0 +1,311 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Compile-time proof of interface implementation.
+var _ NotificationConfigurations = (*notificationConfigurations)(nil)
+
+// NotificationConfigurations describes all the notification configuration
+// related methods that the Terraform Enterprise API supports.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/enterprise/api/notification-configurations.html
+type NotificationConfigurations interface {
+	// List all the notification configurations of the given workspace.
+	List(ctx context.Context, workspaceID string, options NotificationConfigurationListOptions) (*NotificationConfigurationList, error)
+
+	// Create a new notification configuration with the given options.
+	Create(ctx context.Context, workspaceID string, options NotificationConfigurationCreateOptions) (*NotificationConfiguration, error)
+
+	// Read a notification configuration by its ID.
+	Read(ctx context.Context, notificationConfigurationID string) (*NotificationConfiguration, error)
+
+	// Update an existing notification configuration.
+	Update(ctx context.Context, notificationConfigurationID string, options NotificationConfigurationUpdateOptions) (*NotificationConfiguration, error)
+
+	// Delete a notification configuration by its ID.
+	Delete(ctx context.Context, notificationConfigurationID string) error
+
+	// Verify a notification configuration by sending a test payload.
+	Verify(ctx context.Context, notificationConfigurationID string) (*NotificationConfiguration, error)
+
+	// Deliveries returns the delivery history of a notification configuration.
+	Deliveries(ctx context.Context, notificationConfigurationID string) ([]*Delivery, error)
+}
+
+// notificationConfigurations implements NotificationConfigurations.
+type notificationConfigurations struct {
+	client *Client
+}
+
+// NotificationDestinationType represents the destination type of a
+// notification configuration.
+type NotificationDestinationType string
+
+// List of available notification destination types.
+const (
+	NotificationDestinationTypeGeneric NotificationDestinationType = "generic"
+	NotificationDestinationTypeSlack   NotificationDestinationType = "slack"
+)
+
+// List of available notification triggers.
+const (
+	NotificationTriggerCreated        string = "run:created"
+	NotificationTriggerPlanning       string = "run:planning"
+	NotificationTriggerNeedsAttention string = "run:needs_attention"
+	NotificationTriggerApplying       string = "run:applying"
+	NotificationTriggerCompleted      string = "run:completed"
+	NotificationTriggerErrored        string = "run:errored"
+)
+
+// NotificationConfigurationList represents a list of notification
+// configurations.
+type NotificationConfigurationList struct {
+	*Pagination
+	Items []*NotificationConfiguration
+}
+
+// NotificationConfiguration represents a Terraform Enterprise notification
+// configuration.
+type NotificationConfiguration struct {
+	ID                string                      `jsonapi:"primary,notification-configurations"`
+	CreatedAt         time.Time                   `jsonapi:"attr,created-at,iso8601"`
+	DeliveryResponses []*Delivery                 `jsonapi:"attr,delivery-responses"`
+	DestinationType   NotificationDestinationType `jsonapi:"attr,destination-type"`
+	Enabled           bool                        `jsonapi:"attr,enabled"`
+	Name              string                      `jsonapi:"attr,name"`
+	Token             string                      `jsonapi:"attr,token"`
+	Triggers          []string                    `jsonapi:"attr,triggers"`
+	UpdatedAt         time.Time                   `jsonapi:"attr,updated-at,iso8601"`
+	URL               string                      `jsonapi:"attr,url"`
+
+	// Relations
+	Subscribable *Workspace `jsonapi:"relation,subscribable"`
+}
+
+// Delivery represents a single attempt to deliver a notification.
+type Delivery struct {
+	Body       string              `json:"body"`
+	Code       string              `json:"code"`
+	Headers    map[string][]string `json:"headers"`
+	SentAt     time.Time           `json:"sent-at"`
+	Successful string              `json:"successful"`
+	URL        string              `json:"url"`
+}
+
+// NotificationConfigurationListOptions represents the options for listing
+// notification configurations.
+type NotificationConfigurationListOptions struct {
+	ListOptions
+}
+
+// List all the notification configurations of the given workspace.
+func (s *notificationConfigurations) List(ctx context.Context, workspaceID string, options NotificationConfigurationListOptions) (*NotificationConfigurationList, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s/notification-configurations", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	ncl := &NotificationConfigurationList{}
+	err = s.client.do(ctx, req, ncl)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	return ncl, err
+}
+
+// NotificationConfigurationCreateOptions represents the options for
+// creating a new notification configuration.
+type NotificationConfigurationCreateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,notification-configurations"`
+
+	// The destination type of the notification configuration.
+	DestinationType *NotificationDestinationType `jsonapi:"attr,destination-type"`
+
+	// Whether the notification configuration should be enabled or not.
+	Enabled *bool `jsonapi:"attr,enabled"`
+
+	// The name of the notification configuration.
+	Name *string `jsonapi:"attr,name"`
+
+	// An optional token used to sign the notification payloads.
+	Token *string `jsonapi:"attr,token,omitempty"`
+
+	// The run events that will trigger a notification.
+	Triggers []string `jsonapi:"attr,triggers,omitempty"`
+
+	// The URL to send the notifications to.
+	URL *string `jsonapi:"attr,url"`
+}
+
+func (o NotificationConfigurationCreateOptions) valid() error {
+	if o.DestinationType == nil {
+		return errors.New("destination type is required")
+	}
+	if o.Enabled == nil {
+		return errors.New("enabled is required")
+	}
+	if !validString(o.Name) {
+		return errors.New("name is required")
+	}
+	if !validString(o.URL) {
+		return errors.New("url is required")
+	}
+	return nil
+}
+
+// Create a notification configuration with the given options.
+func (s *notificationConfigurations) Create(ctx context.Context, workspaceID string, options NotificationConfigurationCreateOptions) (*NotificationConfiguration, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("workspaces/%s/notification-configurations", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("POST", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := &NotificationConfiguration{}
+	err = s.client.do(ctx, req, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	return nc, nil
+}
+
+// Read a notification configuration by its ID.
+func (s *notificationConfigurations) Read(ctx context.Context, notificationConfigurationID string) (*NotificationConfiguration, error) {
+	if !validStringID(&notificationConfigurationID) {
+		return nil, errors.New("invalid value for notification configuration ID")
+	}
+
+	u := fmt.Sprintf("notification-configurations/%s", url.PathEscape(notificationConfigurationID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := &NotificationConfiguration{}
+	err = s.client.do(ctx, req, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	return nc, nil
+}
+
+// NotificationConfigurationUpdateOptions represents the options for
+// updating an existing notification configuration.
+type NotificationConfigurationUpdateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,notification-configurations"`
+
+	// Whether the notification configuration should be enabled or not.
+	Enabled *bool `jsonapi:"attr,enabled,omitempty"`
+
+	// The name of the notification configuration.
+	Name *string `jsonapi:"attr,name,omitempty"`
+
+	// An optional token used to sign the notification payloads.
+	Token *string `jsonapi:"attr,token,omitempty"`
+
+	// The run events that will trigger a notification.
+	Triggers []string `jsonapi:"attr,triggers,omitempty"`
+
+	// The URL to send the notifications to.
+	URL *string `jsonapi:"attr,url,omitempty"`
+}
+
+// Update an existing notification configuration.
+func (s *notificationConfigurations) Update(ctx context.Context, notificationConfigurationID string, options NotificationConfigurationUpdateOptions) (*NotificationConfiguration, error) {
+	if !validStringID(&notificationConfigurationID) {
+		return nil, errors.New("invalid value for notification configuration ID")
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("notification-configurations/%s", url.PathEscape(notificationConfigurationID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := &NotificationConfiguration{}
+	err = s.client.do(ctx, req, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	return nc, nil
+}
+
+// Delete a notification configuration by its ID.
+func (s *notificationConfigurations) Delete(ctx context.Context, notificationConfigurationID string) error {
+	if !validStringID(&notificationConfigurationID) {
+		return errors.New("invalid value for notification configuration ID")
+	}
+
+	u := fmt.Sprintf("notification-configurations/%s", url.PathEscape(notificationConfigurationID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// Verify a notification configuration by sending a test payload to its URL.
+func (s *notificationConfigurations) Verify(ctx context.Context, notificationConfigurationID string) (*NotificationConfiguration, error) {
+	if !validStringID(&notificationConfigurationID) {
+		return nil, errors.New("invalid value for notification configuration ID")
+	}
+
+	u := fmt.Sprintf("notification-configurations/%s/actions/verify", url.PathEscape(notificationConfigurationID))
+	req, err := s.client.newRequest("POST", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := &NotificationConfiguration{}
+	err = s.client.do(ctx, req, nc)
+	if err != nil {
+		return nil, err
+	}
+
+	return nc, nil
+}
+
+// Deliveries returns the recent delivery attempts of a notification
+// configuration, including the response code and body of each attempt. The
+// API returns the complete (limited) history at once, so there is no need to
+// paginate.
+func (s *notificationConfigurations) Deliveries(ctx context.Context, notificationConfigurationID string) ([]*Delivery, error) {
+	nc, err := s.Read(ctx, notificationConfigurationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return nc.DeliveryResponses, nil
+}
@@ -0,0 +1,184 @@
+package tfe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationConfigurationsList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	ncTest1, _ := createNotificationConfiguration(t, client, wTest)
+	ncTest2, _ := createNotificationConfiguration(t, client, wTest)
+
+	t.Run("with a valid workspace", func(t *testing.T) {
+		ncl, err := client.NotificationConfigurations.List(ctx, wTest.ID, NotificationConfigurationListOptions{})
+		require.NoError(t, err)
+
+		var ids []string
+		for _, nc := range ncl.Items {
+			ids = append(ids, nc.ID)
+		}
+		assert.Contains(t, ids, ncTest1.ID)
+		assert.Contains(t, ids, ncTest2.ID)
+	})
+
+	t.Run("without a valid workspace", func(t *testing.T) {
+		ncl, err := client.NotificationConfigurations.List(ctx, badIdentifier, NotificationConfigurationListOptions{})
+		assert.Nil(t, ncl)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestNotificationConfigurationsCreate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	t.Run("with all required values", func(t *testing.T) {
+		options := NotificationConfigurationCreateOptions{
+			DestinationType: NotificationDestination(NotificationDestinationTypeGeneric),
+			Enabled:         Bool(false),
+			Name:            String(randomString(t)),
+			URL:             String("http://example.com"),
+			Triggers:        []string{NotificationTriggerCreated, NotificationTriggerErrored},
+		}
+
+		nc, err := client.NotificationConfigurations.Create(ctx, wTest.ID, options)
+		require.NoError(t, err)
+
+		assert.Equal(t, *options.DestinationType, nc.DestinationType)
+		assert.Equal(t, *options.Name, nc.Name)
+		assert.Equal(t, *options.URL, nc.URL)
+		assert.Equal(t, options.Triggers, nc.Triggers)
+		assert.False(t, nc.Enabled)
+	})
+
+	t.Run("without a required value", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Create(ctx, wTest.ID, NotificationConfigurationCreateOptions{
+			DestinationType: NotificationDestination(NotificationDestinationTypeGeneric),
+			Enabled:         Bool(false),
+			Name:            String(randomString(t)),
+		})
+		assert.Nil(t, nc)
+		assert.EqualError(t, err, "url is required")
+	})
+
+	t.Run("without a valid workspace", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Create(ctx, badIdentifier, NotificationConfigurationCreateOptions{})
+		assert.Nil(t, nc)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestNotificationConfigurationsRead(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	ncTest, ncTestCleanup := createNotificationConfiguration(t, client, nil)
+	defer ncTestCleanup()
+
+	t.Run("when the notification configuration exists", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Read(ctx, ncTest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, ncTest.ID, nc.ID)
+		assert.Equal(t, ncTest.Name, nc.Name)
+	})
+
+	t.Run("when the notification configuration does not exist", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Read(ctx, "nonexisting")
+		assert.Nil(t, nc)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid notification configuration ID", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Read(ctx, badIdentifier)
+		assert.Nil(t, nc)
+		assert.EqualError(t, err, "invalid value for notification configuration ID")
+	})
+}
+
+func TestNotificationConfigurationsUpdate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	ncTest, ncTestCleanup := createNotificationConfiguration(t, client, nil)
+	defer ncTestCleanup()
+
+	t.Run("with valid options", func(t *testing.T) {
+		options := NotificationConfigurationUpdateOptions{
+			Name:     String(randomString(t)),
+			Triggers: []string{NotificationTriggerCompleted},
+		}
+
+		nc, err := client.NotificationConfigurations.Update(ctx, ncTest.ID, options)
+		require.NoError(t, err)
+
+		assert.Equal(t, *options.Name, nc.Name)
+		assert.Equal(t, options.Triggers, nc.Triggers)
+	})
+
+	t.Run("without a valid notification configuration ID", func(t *testing.T) {
+		nc, err := client.NotificationConfigurations.Update(ctx, badIdentifier, NotificationConfigurationUpdateOptions{})
+		assert.Nil(t, nc)
+		assert.EqualError(t, err, "invalid value for notification configuration ID")
+	})
+}
+
+func TestNotificationConfigurationsDelete(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	ncTest, _ := createNotificationConfiguration(t, client, wTest)
+
+	t.Run("with a valid ID", func(t *testing.T) {
+		err := client.NotificationConfigurations.Delete(ctx, ncTest.ID)
+		require.NoError(t, err)
+
+		_, err = client.NotificationConfigurations.Read(ctx, ncTest.ID)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid notification configuration ID", func(t *testing.T) {
+		err := client.NotificationConfigurations.Delete(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for notification configuration ID")
+	})
+}
+
+func TestNotificationConfigurationsDeliveries(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	ncTest, ncTestCleanup := createNotificationConfiguration(t, client, nil)
+	defer ncTestCleanup()
+
+	t.Run("after verifying the notification configuration", func(t *testing.T) {
+		_, err := client.NotificationConfigurations.Verify(ctx, ncTest.ID)
+		require.NoError(t, err)
+
+		deliveries, err := client.NotificationConfigurations.Deliveries(ctx, ncTest.ID)
+		require.NoError(t, err)
+		require.NotEmpty(t, deliveries)
+
+		assert.Equal(t, ncTest.URL, deliveries[0].URL)
+		assert.False(t, deliveries[0].SentAt.IsZero())
+	})
+
+	t.Run("without a valid notification configuration ID", func(t *testing.T) {
+		deliveries, err := client.NotificationConfigurations.Deliveries(ctx, badIdentifier)
+		assert.Nil(t, deliveries)
+		assert.EqualError(t, err, "invalid value for notification configuration ID")
+	})
+}
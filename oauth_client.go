@@ -86,7 +86,7 @@ func (s *oAuthClients) List(ctx context.Context, organization string, options OA
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/oauth-clients", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/oauth-clients", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -94,11 +94,11 @@ func (s *oAuthClients) List(ctx context.Context, organization string, options OA
 
 	ocl := &OAuthClientList{}
 	err = s.client.do(ctx, req, ocl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return ocl, nil
+	return ocl, err
 }
 
 // OAuthClientCreateOptions represents the options for creating an OAuth client.
@@ -147,7 +147,7 @@ func (s *oAuthClients) Create(ctx context.Context, organization string, options
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s/oauth-clients", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/oauth-clients", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -168,7 +168,7 @@ func (s *oAuthClients) Read(ctx context.Context, oAuthClientID string) (*OAuthCl
 		return nil, errors.New("invalid value for OAuth client ID")
 	}
 
-	u := fmt.Sprintf("oauth-clients/%s", url.QueryEscape(oAuthClientID))
+	u := fmt.Sprintf("oauth-clients/%s", url.PathEscape(oAuthClientID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -189,7 +189,7 @@ func (s *oAuthClients) Delete(ctx context.Context, oAuthClientID string) error {
 		return errors.New("invalid value for OAuth client ID")
 	}
 
-	u := fmt.Sprintf("oauth-clients/%s", url.QueryEscape(oAuthClientID))
+	u := fmt.Sprintf("oauth-clients/%s", url.PathEscape(oAuthClientID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -65,7 +65,7 @@ func (s *oAuthTokens) List(ctx context.Context, organization string, options OAu
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/oauth-tokens", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/oauth-tokens", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -73,11 +73,11 @@ func (s *oAuthTokens) List(ctx context.Context, organization string, options OAu
 
 	otl := &OAuthTokenList{}
 	err = s.client.do(ctx, req, otl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return otl, nil
+	return otl, err
 }
 
 // Read an OAuth token by its ID.
@@ -86,7 +86,7 @@ func (s *oAuthTokens) Read(ctx context.Context, oAuthTokenID string) (*OAuthToke
 		return nil, errors.New("invalid value for OAuth token ID")
 	}
 
-	u := fmt.Sprintf("oauth-tokens/%s", url.QueryEscape(oAuthTokenID))
+	u := fmt.Sprintf("oauth-tokens/%s", url.PathEscape(oAuthTokenID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -119,7 +119,7 @@ func (s *oAuthTokens) Update(ctx context.Context, oAuthTokenID string, options O
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("oauth-tokens/%s", url.QueryEscape(oAuthTokenID))
+	u := fmt.Sprintf("oauth-tokens/%s", url.PathEscape(oAuthTokenID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -140,7 +140,7 @@ func (s *oAuthTokens) Delete(ctx context.Context, oAuthTokenID string) error {
 		return errors.New("invalid value for OAuth token ID")
 	}
 
-	u := fmt.Sprintf("oauth-tokens/%s", url.QueryEscape(oAuthTokenID))
+	u := fmt.Sprintf("oauth-tokens/%s", url.PathEscape(oAuthTokenID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
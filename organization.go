@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -40,6 +42,18 @@ type Organizations interface {
 
 	// RunQueue shows the current run queue of an organization.
 	RunQueue(ctx context.Context, organization string, options RunQueueOptions) (*RunQueue, error)
+
+	// Subscription shows the subscription of an organization, including its
+	// run concurrency limit.
+	Subscription(ctx context.Context, organization string) (*Subscription, error)
+
+	// PendingRuns returns the runs across all workspaces of an organization
+	// that are waiting to be confirmed or to have a policy overridden.
+	PendingRuns(ctx context.Context, organization string) ([]*Run, error)
+
+	// TaskResultCounts counts the run task results across all workspaces of
+	// an organization by task.
+	TaskResultCounts(ctx context.Context, organization string, options TaskResultCountsOptions) ([]*TaskResultCount, error)
 }
 
 // organizations implements Organizations.
@@ -77,6 +91,7 @@ type OrganizationList struct {
 type Organization struct {
 	Name                   string                   `jsonapi:"primary,organizations"`
 	CollaboratorAuthPolicy AuthPolicyType           `jsonapi:"attr,collaborator-auth-policy"`
+	CostEstimationEnabled  bool                     `jsonapi:"attr,cost-estimation-enabled"`
 	CreatedAt              time.Time                `jsonapi:"attr,created-at,iso8601"`
 	Email                  string                   `jsonapi:"attr,email"`
 	EnterprisePlan         EnterprisePlanType       `jsonapi:"attr,enterprise-plan"`
@@ -87,6 +102,9 @@ type Organization struct {
 	SessionTimeout         int                      `jsonapi:"attr,session-timeout"`
 	TrialExpiresAt         time.Time                `jsonapi:"attr,trial-expires-at,iso8601"`
 	TwoFactorConformant    bool                     `jsonapi:"attr,two-factor-conformant"`
+
+	// Relations
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool"`
 }
 
 // Capacity represents the current run capacity of an organization.
@@ -107,6 +125,16 @@ type Entitlements struct {
 	VCSIntegrations       bool   `jsonapi:"attr,vcs-integrations"`
 }
 
+// Subscription represents the subscription of an organization.
+type Subscription struct {
+	ID       string `jsonapi:"primary,subscriptions"`
+	IsActive bool   `jsonapi:"attr,is-active"`
+
+	// The maximum number of runs the organization can execute concurrently.
+	// Any additional runs will be queued until capacity becomes available.
+	RunsCeiling int `jsonapi:"attr,runs-ceiling"`
+}
+
 // RunQueue represents the current run queue of an organization.
 type RunQueue struct {
 	*Pagination
@@ -140,11 +168,11 @@ func (s *organizations) List(ctx context.Context, options OrganizationListOption
 
 	orgl := &OrganizationList{}
 	err = s.client.do(ctx, req, orgl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return orgl, nil
+	return orgl, err
 }
 
 // OrganizationCreateOptions represents the options for creating an organization.
@@ -201,7 +229,7 @@ func (s *organizations) Read(ctx context.Context, organization string) (*Organiz
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -235,6 +263,20 @@ type OrganizationUpdateOptions struct {
 
 	// Authentication policy.
 	CollaboratorAuthPolicy *AuthPolicyType `jsonapi:"attr,collaborator-auth-policy,omitempty"`
+
+	// Enable cost estimation for all workspaces in the organization.
+	CostEstimationEnabled *bool `jsonapi:"attr,cost-estimation-enabled,omitempty"`
+
+	// The agent pool used by new workspaces that use the agent execution
+	// mode. The agent pool must exist and belong to the organization.
+	DefaultAgentPool *AgentPool `jsonapi:"relation,default-agent-pool,omitempty"`
+}
+
+func (o OrganizationUpdateOptions) valid() error {
+	if o.DefaultAgentPool != nil && !validStringID(&o.DefaultAgentPool.ID) {
+		return errors.New("invalid value for default agent pool ID")
+	}
+	return nil
 }
 
 // Update attributes of an existing organization.
@@ -242,11 +284,20 @@ func (s *organizations) Update(ctx context.Context, organization string, options
 	if !validStringID(&organization) {
 		return nil, errors.New("invalid value for organization")
 	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	if options.DefaultAgentPool != nil {
+		if err := s.checkAgentPool(ctx, organization, options.DefaultAgentPool.ID); err != nil {
+			return nil, err
+		}
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s", url.PathEscape(organization))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -261,13 +312,31 @@ func (s *organizations) Update(ctx context.Context, organization string, options
 	return org, nil
 }
 
+// checkAgentPool makes sure the agent pool exists and belongs to the given
+// organization before it is used as the default agent pool.
+func (s *organizations) checkAgentPool(ctx context.Context, organization, agentPoolID string) error {
+	p, err := s.client.AgentPools.Read(ctx, agentPoolID)
+	if errors.Is(err, ErrResourceNotFound) {
+		return fmt.Errorf("agent pool %s does not exist", agentPoolID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if p.Organization != nil && p.Organization.Name != organization {
+		return fmt.Errorf("agent pool %s does not belong to organization %s", agentPoolID, organization)
+	}
+
+	return nil
+}
+
 // Delete an organization by its name.
 func (s *organizations) Delete(ctx context.Context, organization string) error {
 	if !validStringID(&organization) {
 		return errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s", url.PathEscape(organization))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -282,7 +351,7 @@ func (s *organizations) Capacity(ctx context.Context, organization string) (*Cap
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/capacity", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/capacity", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -303,7 +372,7 @@ func (s *organizations) Entitlements(ctx context.Context, organization string) (
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/entitlement-set", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/entitlement-set", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -329,7 +398,7 @@ func (s *organizations) RunQueue(ctx context.Context, organization string, optio
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/runs/queue", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/runs/queue", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -337,9 +406,255 @@ func (s *organizations) RunQueue(ctx context.Context, organization string, optio
 
 	rq := &RunQueue{}
 	err = s.client.do(ctx, req, rq)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	return rq, err
+}
+
+// Subscription shows the subscription of an organization. The subscription
+// holds the run concurrency limit of the organization, which can be combined
+// with the organization's Capacity to determine how many runs can still be
+// started before new runs will be queued.
+func (s *organizations) Subscription(ctx context.Context, organization string) (*Subscription, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+
+	u := fmt.Sprintf("organizations/%s/subscription", url.PathEscape(organization))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &Subscription{}
+	err = s.client.do(ctx, req, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// PendingRuns returns the runs across all workspaces of an organization
+// that are waiting to be confirmed or to have a policy overridden, ordered
+// by their creation time. The workspace relation of each run is populated.
+//
+// The runs of the workspaces are listed concurrently. If listing the runs
+// of one or more workspaces fails, the runs that were found are returned
+// together with a *BulkError keyed by the IDs of the failed workspaces.
+// The runs of each workspace are paged through, newest first, until a page
+// only holds finished runs, as older runs can no longer be waiting.
+func (s *organizations) PendingRuns(ctx context.Context, organization string) ([]*Run, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+
+	wl, err := s.client.listAllWorkspaces(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make(map[string]*Workspace, len(wl))
+	ids := make([]string, len(wl))
+	for i, w := range wl {
+		workspaces[w.ID] = w
+		ids[i] = w.ID
+	}
+
+	return s.pendingRuns(ctx, workspaces, bulkIndexes(len(ids)), ids)
+}
+
+// pendingRuns returns the pending runs of the given workspaces, where
+// indexes holds the index of each workspace ID in the IDs of the original
+// call.
+func (s *organizations) pendingRuns(ctx context.Context, workspaces map[string]*Workspace, indexes []int, ids []string) ([]*Run, error) {
+	var mu sync.Mutex
+	var pending []*Run
+
+	err := forEachIndexed(ctx, indexes, ids, nil, func(ctx context.Context, id string) error {
+		options := RunListOptions{}
+		for {
+			rl, err := s.client.Runs.List(ctx, id, options)
+			if err != nil {
+				return err
+			}
+
+			finished := true
+
+			mu.Lock()
+			for _, r := range rl.Items {
+				if !r.Status.finished() {
+					finished = false
+				}
+				if len(r.BlockReasons()) > 0 {
+					r.Workspace = workspaces[id]
+					pending = append(pending, r)
+				}
+			}
+			mu.Unlock()
+
+			if finished || rl.Pagination == nil || rl.NextPage == 0 {
+				return nil
+			}
+			options.PageNumber = rl.NextPage
+		}
+	})
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+
+	return pending, withRetry(err, func(ctx context.Context, indexes []int, ids []string) (interface{}, error) {
+		return s.pendingRuns(ctx, workspaces, indexes, ids)
+	})
+}
+
+// TaskResultCountsOptions represents the options for counting run task
+// results.
+type TaskResultCountsOptions struct {
+	// Only count the results of runs created at or after this time.
+	Since time.Time
+
+	// Only count the results of runs created before this time. Defaults
+	// to the current time.
+	Until time.Time
+}
+
+func (o TaskResultCountsOptions) valid() error {
+	if o.Since.IsZero() {
+		return errors.New("since is required")
+	}
+	if !o.Until.IsZero() && !o.Until.After(o.Since) {
+		return errors.New("until must be after since")
+	}
+	return nil
+}
+
+// TaskResultCount holds the number of results of a single run task by
+// outcome. Results of unreachable tasks are counted as errored.
+type TaskResultCount struct {
+	TaskName string
+	Passed   int
+	Failed   int
+	Errored  int
+}
+
+// TaskResultCounts counts the results of the run tasks executed for the
+// runs of all workspaces of an organization within the given time range,
+// sorted by task name. Results that are still pending or running are not
+// counted.
+//
+// The runs of the workspaces are processed concurrently. If processing
+// one or more workspaces fails, the counts of the other workspaces are
+// returned together with a *BulkError keyed by the IDs of the failed
+// workspaces.
+func (s *organizations) TaskResultCounts(ctx context.Context, organization string, options TaskResultCountsOptions) ([]*TaskResultCount, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	if options.Until.IsZero() {
+		options.Until = time.Now()
+	}
+
+	wl, err := s.client.listAllWorkspaces(ctx, organization)
 	if err != nil {
 		return nil, err
 	}
 
-	return rq, nil
+	ids := make([]string, len(wl))
+	for i, w := range wl {
+		ids[i] = w.ID
+	}
+
+	return s.taskResultCounts(ctx, options, bulkIndexes(len(ids)), ids)
+}
+
+// taskResultCounts counts the run task results of the given workspaces,
+// where indexes holds the index of each workspace ID in the IDs of the
+// original call.
+func (s *organizations) taskResultCounts(ctx context.Context, options TaskResultCountsOptions, indexes []int, ids []string) ([]*TaskResultCount, error) {
+	var mu sync.Mutex
+	counts := make(map[string]*TaskResultCount)
+
+	err := forEachIndexed(ctx, indexes, ids, nil, func(ctx context.Context, id string) error {
+		runOptions := RunListOptions{}
+		for {
+			rl, err := s.client.Runs.List(ctx, id, runOptions)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range rl.Items {
+				if r.CreatedAt.Before(options.Since) || !r.CreatedAt.Before(options.Until) {
+					continue
+				}
+
+				stages, err := s.client.Runs.TaskStages(ctx, r.ID)
+				if err != nil {
+					return err
+				}
+
+				mu.Lock()
+				for _, ts := range stages {
+					for _, tr := range ts.TaskResults {
+						countTaskResult(counts, tr)
+					}
+				}
+				mu.Unlock()
+			}
+
+			// Runs are listed from newest to oldest, so stop as soon as
+			// the runs were created before the start of the range.
+			last := len(rl.Items) - 1
+			if last < 0 || rl.Items[last].CreatedAt.Before(options.Since) {
+				break
+			}
+
+			if rl.Pagination == nil || rl.NextPage == 0 {
+				break
+			}
+			runOptions.PageNumber = rl.NextPage
+		}
+
+		return nil
+	})
+
+	result := make([]*TaskResultCount, 0, len(counts))
+	for _, c := range counts {
+		result = append(result, c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TaskName < result[j].TaskName
+	})
+
+	return result, withRetry(err, func(ctx context.Context, indexes []int, ids []string) (interface{}, error) {
+		return s.taskResultCounts(ctx, options, indexes, ids)
+	})
+}
+
+// countTaskResult adds the outcome of a task result to the counts.
+func countTaskResult(counts map[string]*TaskResultCount, tr *TaskResult) {
+	c, ok := counts[tr.TaskName]
+	if !ok {
+		c = &TaskResultCount{TaskName: tr.TaskName}
+	}
+
+	switch tr.Status {
+	case TaskResultPassed:
+		c.Passed++
+	case TaskResultFailed:
+		c.Failed++
+	case TaskResultErrored, TaskResultUnreachable:
+		c.Errored++
+	default:
+		return
+	}
+
+	counts[tr.TaskName] = c
 }
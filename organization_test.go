@@ -2,7 +2,12 @@ package tfe
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,10 +138,11 @@ func TestOrganizationsUpdate(t *testing.T) {
 		orgTest, orgTestCleanup := createOrganization(t, client)
 
 		options := OrganizationUpdateOptions{
-			Name:            String(randomString(t)),
-			Email:           String(randomString(t) + "@tfe.local"),
-			SessionTimeout:  Int(3600),
-			SessionRemember: Int(3600),
+			Name:                  String(randomString(t)),
+			Email:                 String(randomString(t) + "@tfe.local"),
+			SessionTimeout:        Int(3600),
+			SessionRemember:       Int(3600),
+			CostEstimationEnabled: Bool(true),
 		}
 
 		org, err := client.Organizations.Update(ctx, orgTest.Name, options)
@@ -161,6 +167,7 @@ func TestOrganizationsUpdate(t *testing.T) {
 			assert.Equal(t, *options.Email, item.Email)
 			assert.Equal(t, *options.SessionTimeout, item.SessionTimeout)
 			assert.Equal(t, *options.SessionRemember, item.SessionRemember)
+			assert.Equal(t, *options.CostEstimationEnabled, item.CostEstimationEnabled)
 		}
 	})
 
@@ -179,6 +186,59 @@ func TestOrganizationsUpdate(t *testing.T) {
 		assert.Equal(t, orgTest.Name, org.Name)
 		assert.Equal(t, orgTest.Email, org.Email)
 	})
+
+	t.Run("with a default agent pool", func(t *testing.T) {
+		orgTest, orgTestCleanup := createOrganization(t, client)
+		defer orgTestCleanup()
+
+		poolTest, _ := createAgentPool(t, client, orgTest)
+
+		org, err := client.Organizations.Update(ctx, orgTest.Name, OrganizationUpdateOptions{
+			DefaultAgentPool: poolTest,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, org.DefaultAgentPool)
+		assert.Equal(t, poolTest.ID, org.DefaultAgentPool.ID)
+
+		refreshed, err := client.Organizations.Read(ctx, orgTest.Name)
+		require.NoError(t, err)
+		require.NotNil(t, refreshed.DefaultAgentPool)
+		assert.Equal(t, poolTest.ID, refreshed.DefaultAgentPool.ID)
+	})
+
+	t.Run("with a default agent pool that does not exist", func(t *testing.T) {
+		orgTest, orgTestCleanup := createOrganization(t, client)
+		defer orgTestCleanup()
+
+		org, err := client.Organizations.Update(ctx, orgTest.Name, OrganizationUpdateOptions{
+			DefaultAgentPool: &AgentPool{ID: "apool-nonexisting"},
+		})
+		assert.Nil(t, org)
+		assert.EqualError(t, err, "agent pool apool-nonexisting does not exist")
+	})
+
+	t.Run("with a default agent pool of another organization", func(t *testing.T) {
+		orgTest, orgTestCleanup := createOrganization(t, client)
+		defer orgTestCleanup()
+
+		poolTest, poolTestCleanup := createAgentPool(t, client, nil)
+		defer poolTestCleanup()
+
+		org, err := client.Organizations.Update(ctx, orgTest.Name, OrganizationUpdateOptions{
+			DefaultAgentPool: poolTest,
+		})
+		assert.Nil(t, org)
+		assert.EqualError(t, err, fmt.Sprintf(
+			"agent pool %s does not belong to organization %s", poolTest.ID, orgTest.Name))
+	})
+
+	t.Run("with an invalid default agent pool ID", func(t *testing.T) {
+		org, err := client.Organizations.Update(ctx, "foo", OrganizationUpdateOptions{
+			DefaultAgentPool: &AgentPool{ID: badIdentifier},
+		})
+		assert.Nil(t, org)
+		assert.EqualError(t, err, "invalid value for default agent pool ID")
+	})
 }
 
 func TestOrganizationsDelete(t *testing.T) {
@@ -365,3 +425,202 @@ func TestOrganizationsRunQueue(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestOrganizationsSubscription(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	t.Run("when the org exists", func(t *testing.T) {
+		sub, err := client.Organizations.Subscription(ctx, orgTest.Name)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, sub.ID)
+		assert.True(t, sub.IsActive)
+		assert.True(t, sub.RunsCeiling > 0)
+	})
+
+	t.Run("with invalid name", func(t *testing.T) {
+		sub, err := client.Organizations.Subscription(ctx, badIdentifier)
+		assert.Nil(t, sub)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+
+	t.Run("when the org does not exist", func(t *testing.T) {
+		_, err := client.Organizations.Subscription(ctx, randomString(t))
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+}
+
+func TestOrganizationsPendingRuns(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	createWorkspace(t, client, orgTest)
+	createWorkspace(t, client, orgTest)
+
+	t.Run("without pending runs", func(t *testing.T) {
+		runs, err := client.Organizations.PendingRuns(ctx, orgTest.Name)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("with a planned run waiting for confirmation", func(t *testing.T) {
+		wTest, wTestCleanup := createWorkspace(t, client, orgTest)
+		defer wTestCleanup()
+		require.False(t, wTest.AutoApply)
+
+		rTest, rTestCleanup := createPlannedRun(t, client, wTest)
+		defer rTestCleanup()
+
+		runs, err := client.Organizations.PendingRuns(ctx, orgTest.Name)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, rTest.ID, runs[0].ID)
+		require.NotNil(t, runs[0].Workspace)
+		assert.Equal(t, wTest.ID, runs[0].Workspace.ID)
+		assert.Equal(t, wTest.Name, runs[0].Workspace.Name)
+	})
+
+	t.Run("with invalid name", func(t *testing.T) {
+		runs, err := client.Organizations.PendingRuns(ctx, badIdentifier)
+		assert.Nil(t, runs)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestOrganizationsPendingRunsPaging(t *testing.T) {
+	pages := map[string]string{
+		"1": `[
+			{"type": "runs", "id": "run-4", "attributes": {"status": "pending", "created-at": "2022-01-04T00:00:00Z"}},
+			{"type": "runs", "id": "run-3", "attributes": {"status": "cost_estimated", "created-at": "2022-01-03T00:00:00Z",
+				"actions": {"is-confirmable": true}, "permissions": {"can-apply": true}}}
+		]`,
+		"2": `[
+			{"type": "runs", "id": "run-2", "attributes": {"status": "policy_override", "created-at": "2022-01-02T00:00:00Z"}},
+			{"type": "runs", "id": "run-1", "attributes": {"status": "applied", "created-at": "2022-01-01T00:00:00Z"}}
+		]`,
+		"3": `[
+			{"type": "runs", "id": "run-0", "attributes": {"status": "discarded", "created-at": "2021-12-31T00:00:00Z"}}
+		]`,
+	}
+
+	var mu sync.Mutex
+	var requested []string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+
+		switch r.URL.Path {
+		case "/api/v2/organizations/org-test/workspaces":
+			w.Write([]byte(`{"data": [{"type": "workspaces", "id": "ws-1", "attributes": {"name": "one"}}]}`))
+		case "/api/v2/workspaces/ws-1/runs":
+			page := r.URL.Query().Get("page[number]")
+			if page == "" {
+				page = "1"
+			}
+			mu.Lock()
+			requested = append(requested, page)
+			mu.Unlock()
+
+			// Page 3 only holds finished runs, so page 4 must never
+			// be requested even though it is advertised.
+			next := int(page[0]-'0') + 1
+			fmt.Fprintf(w, `{"data": %s, "meta": {"pagination": {"current-page": %s, "next-page": %d}}}`,
+				pages[page], page, next)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	runs, err := client.Organizations.PendingRuns(context.Background(), "org-test")
+	require.NoError(t, err)
+
+	var ids []string
+	for _, r := range runs {
+		ids = append(ids, r.ID)
+		require.NotNil(t, r.Workspace)
+		assert.Equal(t, "ws-1", r.Workspace.ID)
+	}
+	assert.Equal(t, []string{"run-2", "run-3"}, ids)
+	assert.Equal(t, []string{"1", "2", "3"}, requested)
+}
+
+func TestOrganizationsTaskResultCounts(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	createWorkspace(t, client, orgTest)
+
+	t.Run("without run tasks", func(t *testing.T) {
+		counts, err := client.Organizations.TaskResultCounts(ctx, orgTest.Name, TaskResultCountsOptions{
+			Since: time.Now().Add(-7 * 24 * time.Hour),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+	})
+
+	t.Run("without a start time", func(t *testing.T) {
+		counts, err := client.Organizations.TaskResultCounts(ctx, orgTest.Name, TaskResultCountsOptions{})
+		assert.Nil(t, counts)
+		assert.EqualError(t, err, "since is required")
+	})
+
+	t.Run("when until is before since", func(t *testing.T) {
+		counts, err := client.Organizations.TaskResultCounts(ctx, orgTest.Name, TaskResultCountsOptions{
+			Since: time.Now(),
+			Until: time.Now().Add(-time.Hour),
+		})
+		assert.Nil(t, counts)
+		assert.EqualError(t, err, "until must be after since")
+	})
+
+	t.Run("with invalid name", func(t *testing.T) {
+		counts, err := client.Organizations.TaskResultCounts(ctx, badIdentifier, TaskResultCountsOptions{
+			Since: time.Now().Add(-time.Hour),
+		})
+		assert.Nil(t, counts)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestCountTaskResult(t *testing.T) {
+	counts := make(map[string]*TaskResultCount)
+	for _, tr := range []*TaskResult{
+		{TaskName: "lint", Status: TaskResultPassed},
+		{TaskName: "lint", Status: TaskResultFailed},
+		{TaskName: "lint", Status: TaskResultPassed},
+		{TaskName: "scan", Status: TaskResultErrored},
+		{TaskName: "scan", Status: TaskResultUnreachable},
+		{TaskName: "scan", Status: TaskResultRunning},
+		{TaskName: "cost", Status: TaskResultPending},
+	} {
+		countTaskResult(counts, tr)
+	}
+
+	assert.Equal(t, map[string]*TaskResultCount{
+		"lint": {TaskName: "lint", Passed: 2, Failed: 1},
+		"scan": {TaskName: "scan", Errored: 2},
+	}, counts)
+}
@@ -47,7 +47,7 @@ func (s *organizationTokens) Generate(ctx context.Context, organization string)
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/authentication-token", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/authentication-token", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, nil)
 	if err != nil {
 		return nil, err
@@ -68,7 +68,7 @@ func (s *organizationTokens) Read(ctx context.Context, organization string) (*Or
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/authentication-token", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/authentication-token", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -89,7 +89,7 @@ func (s *organizationTokens) Delete(ctx context.Context, organization string) er
 		return errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/authentication-token", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/authentication-token", url.PathEscape(organization))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -73,7 +73,7 @@ func (s *plans) Read(ctx context.Context, planID string) (*Plan, error) {
 		return nil, errors.New("invalid value for plan ID")
 	}
 
-	u := fmt.Sprintf("plans/%s", url.QueryEscape(planID))
+	u := fmt.Sprintf("plans/%s", url.PathEscape(planID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -93,7 +93,7 @@ func (s *policies) List(ctx context.Context, organization string, options Policy
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/policies", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/policies", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -101,11 +101,11 @@ func (s *policies) List(ctx context.Context, organization string, options Policy
 
 	pl := &PolicyList{}
 	err = s.client.do(ctx, req, pl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return pl, nil
+	return pl, err
 }
 
 // PolicyCreateOptions represents the options for creating a new policy.
@@ -162,7 +162,7 @@ func (s *policies) Create(ctx context.Context, organization string, options Poli
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s/policies", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/policies", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -183,7 +183,7 @@ func (s *policies) Read(ctx context.Context, policyID string) (*Policy, error) {
 		return nil, errors.New("invalid value for policy ID")
 	}
 
-	u := fmt.Sprintf("policies/%s", url.QueryEscape(policyID))
+	u := fmt.Sprintf("policies/%s", url.PathEscape(policyID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -219,7 +219,7 @@ func (s *policies) Update(ctx context.Context, policyID string, options PolicyUp
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("policies/%s", url.QueryEscape(policyID))
+	u := fmt.Sprintf("policies/%s", url.PathEscape(policyID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -240,7 +240,7 @@ func (s *policies) Delete(ctx context.Context, policyID string) error {
 		return errors.New("invalid value for policy ID")
 	}
 
-	u := fmt.Sprintf("policies/%s", url.QueryEscape(policyID))
+	u := fmt.Sprintf("policies/%s", url.PathEscape(policyID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -255,7 +255,7 @@ func (s *policies) Upload(ctx context.Context, policyID string, content []byte)
 		return errors.New("invalid value for policy ID")
 	}
 
-	u := fmt.Sprintf("policies/%s/upload", url.QueryEscape(policyID))
+	u := fmt.Sprintf("policies/%s/upload", url.PathEscape(policyID))
 	req, err := s.client.newRequest("PUT", u, content)
 	if err != nil {
 		return err
@@ -270,14 +270,14 @@ func (s *policies) Download(ctx context.Context, policyID string) ([]byte, error
 		return nil, errors.New("invalid value for policy ID")
 	}
 
-	u := fmt.Sprintf("policies/%s/download", url.QueryEscape(policyID))
+	u := fmt.Sprintf("policies/%s/download", url.PathEscape(policyID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var buf bytes.Buffer
-	err = s.client.do(ctx, req, &buf)
+	err = s.client.do(withDownload(ctx), req, &buf)
 	if err != nil {
 		return nil, err
 	}
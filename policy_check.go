@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -30,6 +32,10 @@ type PolicyChecks interface {
 
 	// Logs retrieves the logs of a policy check.
 	Logs(ctx context.Context, policyCheckID string) (io.Reader, error)
+
+	// Evaluations returns the outcome of each policy evaluated by a policy
+	// check, together with its policy set.
+	Evaluations(ctx context.Context, policyCheckID string) ([]*PolicyEvaluation, error)
 }
 
 // policyChecks implements PolicyChecks.
@@ -77,6 +83,9 @@ type PolicyCheck struct {
 	Scope            PolicyScope             `jsonapi:"attr,scope"`
 	Status           PolicyStatus            `jsonapi:"attr,status"`
 	StatusTimestamps *PolicyStatusTimestamps `jsonapi:"attr,status-timestamps"`
+
+	// Relations
+	Run *Run `jsonapi:"relation,run"`
 }
 
 // PolicyActions represents the policy check actions.
@@ -91,14 +100,38 @@ type PolicyPermissions struct {
 
 // PolicyResult represents the complete policy check result,
 type PolicyResult struct {
-	AdvisoryFailed int  `json:"advisory-failed"`
-	Duration       int  `json:"duration"`
-	HardFailed     int  `json:"hard-failed"`
-	Passed         int  `json:"passed"`
-	Result         bool `json:"result"`
-	// Sentinel       *sentinel.EvalResult `json:"sentinel"`
-	SoftFailed  int `json:"soft-failed"`
-	TotalFailed int `json:"total-failed"`
+	AdvisoryFailed int             `json:"advisory-failed"`
+	Duration       int             `json:"duration"`
+	HardFailed     int             `json:"hard-failed"`
+	Passed         int             `json:"passed"`
+	Result         bool            `json:"result"`
+	Sentinel       *SentinelResult `json:"sentinel"`
+	SoftFailed     int             `json:"soft-failed"`
+	TotalFailed    int             `json:"total-failed"`
+}
+
+// SentinelResult represents the detailed Sentinel result of a policy check.
+type SentinelResult struct {
+	SchemaVersion string `json:"schema-version"`
+
+	// The results of the evaluated policy sets, keyed by policy set name.
+	Data map[string]*SentinelPolicySetResult `json:"data"`
+}
+
+// SentinelPolicySetResult represents the Sentinel result of a policy set.
+type SentinelPolicySetResult struct {
+	CanOverride bool                    `json:"can-override"`
+	Policies    []*SentinelPolicyResult `json:"policies"`
+	Result      bool                    `json:"result"`
+}
+
+// SentinelPolicyResult represents the Sentinel result of a single policy.
+type SentinelPolicyResult struct {
+	AllowedFailure bool `json:"allowed-failure"`
+
+	// The policy, in the form policy-set/policy.
+	Policy string `json:"policy"`
+	Result bool   `json:"result"`
 }
 
 // PolicyStatusTimestamps holds the timestamps for individual policy check
@@ -122,7 +155,7 @@ func (s *policyChecks) List(ctx context.Context, runID string, options PolicyChe
 		return nil, errors.New("invalid value for run ID")
 	}
 
-	u := fmt.Sprintf("runs/%s/policy-checks", url.QueryEscape(runID))
+	u := fmt.Sprintf("runs/%s/policy-checks", url.PathEscape(runID))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -130,11 +163,11 @@ func (s *policyChecks) List(ctx context.Context, runID string, options PolicyChe
 
 	pcl := &PolicyCheckList{}
 	err = s.client.do(ctx, req, pcl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return pcl, nil
+	return pcl, err
 }
 
 // Read a policy check by its ID.
@@ -143,7 +176,7 @@ func (s *policyChecks) Read(ctx context.Context, policyCheckID string) (*PolicyC
 		return nil, errors.New("invalid value for policy check ID")
 	}
 
-	u := fmt.Sprintf("policy-checks/%s", url.QueryEscape(policyCheckID))
+	u := fmt.Sprintf("policy-checks/%s", url.PathEscape(policyCheckID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -164,7 +197,7 @@ func (s *policyChecks) Override(ctx context.Context, policyCheckID string) (*Pol
 		return nil, errors.New("invalid value for policy check ID")
 	}
 
-	u := fmt.Sprintf("policy-checks/%s/actions/override", url.QueryEscape(policyCheckID))
+	u := fmt.Sprintf("policy-checks/%s/actions/override", url.PathEscape(policyCheckID))
 	req, err := s.client.newRequest("POST", u, nil)
 	if err != nil {
 		return nil, err
@@ -204,7 +237,7 @@ func (s *policyChecks) Logs(ctx context.Context, policyCheckID string) (io.Reade
 			}
 		}
 
-		u := fmt.Sprintf("policy-checks/%s/output", url.QueryEscape(policyCheckID))
+		u := fmt.Sprintf("policy-checks/%s/output", url.PathEscape(policyCheckID))
 		req, err := s.client.newRequest("GET", u, nil)
 		if err != nil {
 			return nil, err
@@ -219,3 +252,185 @@ func (s *policyChecks) Logs(ctx context.Context, policyCheckID string) (io.Reade
 		return logs, nil
 	}
 }
+
+// PolicyEvaluation represents the outcome of a single policy evaluated by a
+// policy check.
+type PolicyEvaluation struct {
+	PolicyName    string
+	PolicySetName string
+	Passed        bool
+
+	// The enforcement level the policy was evaluated with. This is empty
+	// when it cannot be determined, for example for a passed policy that
+	// was changed or deleted after the policy check.
+	EnforcementLevel EnforcementLevel
+
+	// The evaluated policy and its policy set. These are nil when they
+	// could not be found, for example because they were deleted after
+	// the policy check.
+	Policy    *Policy
+	PolicySet *PolicySet
+}
+
+// Evaluations returns the outcome of each policy evaluated by the given
+// policy check, sorted by policy set and policy name. The policies and
+// policy sets are looked up in the organization of the checked run to link
+// them to their current version. The enforcement level of each policy is
+// taken from its current version if it was not changed since the policy
+// check, and is otherwise derived from the Sentinel result.
+func (s *policyChecks) Evaluations(ctx context.Context, policyCheckID string) ([]*PolicyEvaluation, error) {
+	pc, err := s.Read(ctx, policyCheckID)
+	if err != nil {
+		return nil, err
+	}
+	if pc.Result == nil || pc.Result.Sentinel == nil {
+		return nil, nil
+	}
+	if pc.Run == nil {
+		return nil, fmt.Errorf("policy check %s does not have a run", policyCheckID)
+	}
+
+	organization, err := s.runOrganization(ctx, pc.Run.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	policySets := make(map[string]*PolicySet)
+
+	psOptions := PolicySetListOptions{}
+	for {
+		psl, err := s.client.PolicySets.List(ctx, organization, psOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ps := range psl.Items {
+			policySets[ps.Name] = ps
+		}
+
+		if psl.Pagination == nil || psl.NextPage == 0 {
+			break
+		}
+		psOptions.PageNumber = psl.NextPage
+	}
+
+	policies := make(map[string]*Policy)
+
+	pOptions := PolicyListOptions{}
+	for {
+		pl, err := s.client.Policies.List(ctx, organization, pOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range pl.Items {
+			policies[p.Name] = p
+		}
+
+		if pl.Pagination == nil || pl.NextPage == 0 {
+			break
+		}
+		pOptions.PageNumber = pl.NextPage
+	}
+
+	var checkedAt time.Time
+	if pc.StatusTimestamps != nil {
+		checkedAt = pc.StatusTimestamps.QueuedAt
+	}
+
+	return policyEvaluations(pc.Result.Sentinel, checkedAt, policySets, policies), nil
+}
+
+// runOrganization returns the name of the organization of the given run.
+func (s *policyChecks) runOrganization(ctx context.Context, runID string) (string, error) {
+	r, err := s.client.Runs.Read(ctx, runID)
+	if err != nil {
+		return "", err
+	}
+	if r.Workspace == nil {
+		return "", fmt.Errorf("run %s does not have a workspace", runID)
+	}
+
+	w, err := s.client.readWorkspaceByID(ctx, r.Workspace.ID)
+	if err != nil {
+		return "", err
+	}
+	if w.Organization == nil {
+		return "", fmt.Errorf("workspace %s does not have an organization", r.Workspace.ID)
+	}
+
+	return w.Organization.Name, nil
+}
+
+// policyEvaluations combines a Sentinel result of a policy check queued at
+// checkedAt with the given policy sets and policies, keyed by name.
+func policyEvaluations(result *SentinelResult, checkedAt time.Time, policySets map[string]*PolicySet, policies map[string]*Policy) []*PolicyEvaluation {
+	var evaluations []*PolicyEvaluation
+
+	for setName, setResult := range result.Data {
+		if setResult == nil {
+			continue
+		}
+
+		// The number of failed policies that are not advisory.
+		failed := 0
+		for _, pr := range setResult.Policies {
+			if !pr.Result && !pr.AllowedFailure {
+				failed++
+			}
+		}
+
+		for _, pr := range setResult.Policies {
+			name := strings.TrimPrefix(pr.Policy, setName+"/")
+
+			e := &PolicyEvaluation{
+				PolicyName:    name,
+				PolicySetName: setName,
+				Passed:        pr.Result,
+				Policy:        policies[name],
+				PolicySet:     policySets[setName],
+			}
+
+			// Failures of advisory policies are allowed. A policy set
+			// can only be overridden if all its failures are soft
+			// mandatory, so a single failure that cannot be overridden
+			// must be hard mandatory.
+			level, ok := policyEnforcementLevel(e.Policy, checkedAt)
+			switch {
+			case pr.AllowedFailure:
+				e.EnforcementLevel = EnforcementAdvisory
+			case ok:
+				e.EnforcementLevel = level
+			case !pr.Result && setResult.CanOverride:
+				e.EnforcementLevel = EnforcementSoft
+			case !pr.Result && failed == 1:
+				e.EnforcementLevel = EnforcementHard
+			}
+
+			evaluations = append(evaluations, e)
+		}
+	}
+
+	sort.Slice(evaluations, func(i, j int) bool {
+		if evaluations[i].PolicySetName != evaluations[j].PolicySetName {
+			return evaluations[i].PolicySetName < evaluations[j].PolicySetName
+		}
+		return evaluations[i].PolicyName < evaluations[j].PolicyName
+	})
+
+	return evaluations
+}
+
+// policyEnforcementLevel returns the enforcement level of the given policy,
+// if the policy was not changed since checkedAt.
+func policyEnforcementLevel(p *Policy, checkedAt time.Time) (EnforcementLevel, bool) {
+	if p == nil || checkedAt.IsZero() || p.UpdatedAt.After(checkedAt) {
+		return "", false
+	}
+	for _, e := range p.Enforce {
+		if e.Path == p.Name+".sentinel" {
+			return e.Mode, true
+		}
+	}
+	return "", false
+}
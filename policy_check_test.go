@@ -4,6 +4,7 @@ import (
 	"context"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -116,6 +117,164 @@ func TestPolicyChecksRead(t *testing.T) {
 	})
 }
 
+func TestPolicyChecksEvaluations(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	pTest, _ := createUploadedPolicy(t, client, true, orgTest)
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	psTest, _ := createPolicySet(t, client, orgTest, []*Policy{pTest}, []*Workspace{wTest})
+
+	rTest, _ := createPlannedRun(t, client, wTest)
+	require.Equal(t, 1, len(rTest.PolicyChecks))
+
+	t.Run("when the policy check exists", func(t *testing.T) {
+		evaluations, err := client.PolicyChecks.Evaluations(ctx, rTest.PolicyChecks[0].ID)
+		require.NoError(t, err)
+		require.Len(t, evaluations, 1)
+
+		e := evaluations[0]
+		assert.Equal(t, pTest.Name, e.PolicyName)
+		assert.Equal(t, psTest.Name, e.PolicySetName)
+		assert.Equal(t, pTest.Enforce[0].Mode, e.EnforcementLevel)
+		assert.True(t, e.Passed)
+		require.NotNil(t, e.Policy)
+		assert.Equal(t, pTest.ID, e.Policy.ID)
+		require.NotNil(t, e.PolicySet)
+		assert.Equal(t, psTest.ID, e.PolicySet.ID)
+	})
+
+	t.Run("without a valid policy check ID", func(t *testing.T) {
+		evaluations, err := client.PolicyChecks.Evaluations(ctx, badIdentifier)
+		assert.Nil(t, evaluations)
+		assert.EqualError(t, err, "invalid value for policy check ID")
+	})
+}
+
+func TestPolicyEvaluations(t *testing.T) {
+	checkedAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("when the policies changed", func(t *testing.T) {
+		result := &SentinelResult{
+			Data: map[string]*SentinelPolicySetResult{
+				"networking": {
+					Policies: []*SentinelPolicyResult{
+						{Policy: "networking/restrict-ports", Result: false},
+						{Policy: "networking/require-tags", Result: true},
+					},
+				},
+				"cost": {
+					CanOverride: true,
+					Policies: []*SentinelPolicyResult{
+						{Policy: "cost/limit-spend", Result: false, AllowedFailure: true},
+						{Policy: "cost/limit-instances", Result: false},
+					},
+				},
+			},
+		}
+
+		networking := &PolicySet{ID: "polset-1", Name: "networking"}
+		// The enforcement level was changed after the policy check.
+		restrictPorts := &Policy{
+			ID:        "pol-1",
+			Name:      "restrict-ports",
+			Enforce:   []*Enforcement{{Path: "restrict-ports.sentinel", Mode: EnforcementAdvisory}},
+			UpdatedAt: checkedAt.Add(time.Hour),
+		}
+
+		evaluations := policyEvaluations(result, checkedAt,
+			map[string]*PolicySet{"networking": networking},
+			map[string]*Policy{"restrict-ports": restrictPorts},
+		)
+
+		assert.Equal(t, []*PolicyEvaluation{
+			{
+				PolicyName:       "limit-instances",
+				PolicySetName:    "cost",
+				EnforcementLevel: EnforcementSoft,
+			},
+			{
+				PolicyName:       "limit-spend",
+				PolicySetName:    "cost",
+				EnforcementLevel: EnforcementAdvisory,
+			},
+			{
+				PolicyName:    "require-tags",
+				PolicySetName: "networking",
+				Passed:        true,
+				PolicySet:     networking,
+			},
+			{
+				PolicyName:       "restrict-ports",
+				PolicySetName:    "networking",
+				EnforcementLevel: EnforcementHard,
+				Policy:           restrictPorts,
+				PolicySet:        networking,
+			},
+		}, evaluations)
+	})
+
+	t.Run("with mixed enforcement levels in a policy set", func(t *testing.T) {
+		result := &SentinelResult{
+			Data: map[string]*SentinelPolicySetResult{
+				"mixed": {
+					Policies: []*SentinelPolicyResult{
+						{Policy: "mixed/deny-public", Result: false},
+						{Policy: "mixed/limit-size", Result: false},
+						{Policy: "mixed/require-owner", Result: true},
+						{Policy: "mixed/deleted", Result: false},
+					},
+				},
+			},
+		}
+
+		policy := func(name string, mode EnforcementLevel) *Policy {
+			return &Policy{
+				Name:      name,
+				Enforce:   []*Enforcement{{Path: name + ".sentinel", Mode: mode}},
+				UpdatedAt: checkedAt.Add(-time.Hour),
+			}
+		}
+		policies := map[string]*Policy{
+			"deny-public":   policy("deny-public", EnforcementHard),
+			"limit-size":    policy("limit-size", EnforcementSoft),
+			"require-owner": policy("require-owner", EnforcementHard),
+		}
+
+		evaluations := policyEvaluations(result, checkedAt, nil, policies)
+
+		assert.Equal(t, []*PolicyEvaluation{
+			{
+				PolicyName:    "deleted",
+				PolicySetName: "mixed",
+			},
+			{
+				PolicyName:       "deny-public",
+				PolicySetName:    "mixed",
+				EnforcementLevel: EnforcementHard,
+				Policy:           policies["deny-public"],
+			},
+			{
+				PolicyName:       "limit-size",
+				PolicySetName:    "mixed",
+				EnforcementLevel: EnforcementSoft,
+				Policy:           policies["limit-size"],
+			},
+			{
+				PolicyName:       "require-owner",
+				PolicySetName:    "mixed",
+				EnforcementLevel: EnforcementHard,
+				Passed:           true,
+				Policy:           policies["require-owner"],
+			},
+		}, evaluations)
+	})
+}
+
 func TestPolicyChecksOverride(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -86,7 +86,7 @@ func (s *policySets) List(ctx context.Context, organization string, options Poli
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/policy-sets", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/policy-sets", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -94,11 +94,11 @@ func (s *policySets) List(ctx context.Context, organization string, options Poli
 
 	psl := &PolicySetList{}
 	err = s.client.do(ctx, req, psl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return psl, nil
+	return psl, err
 }
 
 // PolicySetCreateOptions represents the options for creating a new policy set.
@@ -144,7 +144,7 @@ func (s *policySets) Create(ctx context.Context, organization string, options Po
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s/policy-sets", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/policy-sets", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -165,7 +165,7 @@ func (s *policySets) Read(ctx context.Context, policySetID string) (*PolicySet,
 		return nil, errors.New("invalid value for policy set ID")
 	}
 
-	u := fmt.Sprintf("policy-sets/%s", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -214,7 +214,7 @@ func (s *policySets) Update(ctx context.Context, policySetID string, options Pol
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("policy-sets/%s", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -255,7 +255,7 @@ func (s *policySets) AddPolicies(ctx context.Context, policySetID string, option
 		return err
 	}
 
-	u := fmt.Sprintf("policy-sets/%s/relationships/policies", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s/relationships/policies", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("POST", u, options.Policies)
 	if err != nil {
 		return err
@@ -290,7 +290,7 @@ func (s *policySets) RemovePolicies(ctx context.Context, policySetID string, opt
 		return err
 	}
 
-	u := fmt.Sprintf("policy-sets/%s/relationships/policies", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s/relationships/policies", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("DELETE", u, options.Policies)
 	if err != nil {
 		return err
@@ -325,7 +325,7 @@ func (s *policySets) AddWorkspaces(ctx context.Context, policySetID string, opti
 		return err
 	}
 
-	u := fmt.Sprintf("policy-sets/%s/relationships/workspaces", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s/relationships/workspaces", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("POST", u, options.Workspaces)
 	if err != nil {
 		return err
@@ -360,7 +360,7 @@ func (s *policySets) RemoveWorkspaces(ctx context.Context, policySetID string, o
 		return err
 	}
 
-	u := fmt.Sprintf("policy-sets/%s/relationships/workspaces", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s/relationships/workspaces", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("DELETE", u, options.Workspaces)
 	if err != nil {
 		return err
@@ -375,7 +375,7 @@ func (s *policySets) Delete(ctx context.Context, policySetID string) error {
 		return errors.New("invalid value for policy set ID")
 	}
 
-	u := fmt.Sprintf("policy-sets/%s", url.QueryEscape(policySetID))
+	u := fmt.Sprintf("policy-sets/%s", url.PathEscape(policySetID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,107 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Compile-time proof of interface implementation.
+var _ Projects = (*projects)(nil)
+
+// Projects describes all the project related methods that the Terraform
+// Enterprise API supports.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/cloud/api/projects.html
+type Projects interface {
+	// List all the projects within an organization.
+	List(ctx context.Context, organization string, options ProjectListOptions) (*ProjectList, error)
+}
+
+// projects implements Projects.
+type projects struct {
+	client *Client
+}
+
+// ProjectList represents a list of projects.
+type ProjectList struct {
+	*Pagination
+	Items []*Project
+}
+
+// Project represents a Terraform Enterprise project.
+type Project struct {
+	ID   string `jsonapi:"primary,projects"`
+	Name string `jsonapi:"attr,name"`
+
+	// The number of workspaces in the project. This is only set when
+	// listing projects with ProjectListOptions.WorkspaceCounts enabled.
+	WorkspaceCount int
+
+	// Relations
+	Organization *Organization `jsonapi:"relation,organization"`
+}
+
+// ProjectListOptions represents the options for listing projects.
+type ProjectListOptions struct {
+	ListOptions
+
+	// Count the workspaces of each listed project. The API does not report
+	// these counts, so all workspaces of the organization are listed to
+	// count them. This happens on every call, so walking through the pages
+	// of projects lists all workspaces again for each page. The counts are
+	// exact, but as they are computed from separate requests, workspaces
+	// created or moved during the listing may be missed.
+	WorkspaceCounts bool `url:"-"`
+}
+
+// List all the projects within an organization.
+func (s *projects) List(ctx context.Context, organization string, options ProjectListOptions) (*ProjectList, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+
+	u := fmt.Sprintf("organizations/%s/projects", url.PathEscape(organization))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	pl := &ProjectList{}
+	err = s.client.do(ctx, req, pl)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	if options.WorkspaceCounts {
+		if err := s.countWorkspaces(ctx, organization, pl.Items); err != nil {
+			return nil, err
+		}
+	}
+
+	return pl, err
+}
+
+// countWorkspaces sets the workspace count of the given projects by listing
+// all workspaces of the organization.
+func (s *projects) countWorkspaces(ctx context.Context, organization string, projects []*Project) error {
+	workspaces, err := s.client.listAllWorkspaces(ctx, organization)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	for _, w := range workspaces {
+		if w.Project != nil {
+			counts[w.Project.ID]++
+		}
+	}
+
+	for _, p := range projects {
+		p.WorkspaceCount = counts[p.ID]
+	}
+
+	return nil
+}
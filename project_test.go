@@ -0,0 +1,51 @@
+package tfe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectsList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	createWorkspace(t, client, orgTest)
+	createWorkspace(t, client, orgTest)
+
+	t.Run("without list options", func(t *testing.T) {
+		pl, err := client.Projects.List(ctx, orgTest.Name, ProjectListOptions{})
+		require.NoError(t, err)
+		require.NotEmpty(t, pl.Items)
+
+		for _, p := range pl.Items {
+			assert.NotEmpty(t, p.ID)
+			assert.Zero(t, p.WorkspaceCount)
+		}
+	})
+
+	t.Run("with workspace counts", func(t *testing.T) {
+		pl, err := client.Projects.List(ctx, orgTest.Name, ProjectListOptions{
+			WorkspaceCounts: true,
+		})
+		require.NoError(t, err)
+
+		// New workspaces are created in the default project.
+		total := 0
+		for _, p := range pl.Items {
+			total += p.WorkspaceCount
+		}
+		assert.Equal(t, 2, total)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		pl, err := client.Projects.List(ctx, badIdentifier, ProjectListOptions{})
+		assert.Nil(t, pl)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -36,6 +38,12 @@ type Runs interface {
 
 	// Discard a run by its ID.
 	Discard(ctx context.Context, runID string, options RunDiscardOptions) error
+
+	// Logs retrieves the combined plan and apply logs of a run.
+	Logs(ctx context.Context, runID string) (io.Reader, error)
+
+	// TaskStages returns the task stages of a run, including their results.
+	TaskStages(ctx context.Context, runID string) ([]*TaskStage, error)
 }
 
 // runs implements Runs.
@@ -49,12 +57,16 @@ type RunStatus string
 //List all available run statuses.
 const (
 	RunApplied            RunStatus = "applied"
+	RunApplyQueued        RunStatus = "apply_queued"
 	RunApplying           RunStatus = "applying"
 	RunCanceled           RunStatus = "canceled"
 	RunConfirmed          RunStatus = "confirmed"
+	RunCostEstimated      RunStatus = "cost_estimated"
+	RunCostEstimating     RunStatus = "cost_estimating"
 	RunDiscarded          RunStatus = "discarded"
 	RunErrored            RunStatus = "errored"
 	RunPending            RunStatus = "pending"
+	RunPlanQueued         RunStatus = "plan_queued"
 	RunPlanned            RunStatus = "planned"
 	RunPlannedAndFinished RunStatus = "planned_and_finished"
 	RunPlanning           RunStatus = "planning"
@@ -64,6 +76,17 @@ const (
 	RunPolicySoftFailed   RunStatus = "policy_soft_failed"
 )
 
+// finished reports whether a run with this status has reached a final state.
+func (s RunStatus) finished() bool {
+	switch s {
+	case RunApplied, RunCanceled, RunDiscarded, RunErrored,
+		RunPlannedAndFinished, RunPolicySoftFailed:
+		return true
+	default:
+		return false
+	}
+}
+
 // RunSource represents a source type of a run.
 type RunSource string
 
@@ -95,6 +118,11 @@ type Run struct {
 	Status                 RunStatus            `jsonapi:"attr,status"`
 	StatusTimestamps       *RunStatusTimestamps `jsonapi:"attr,status-timestamps"`
 
+	// The Terraform version used by the run. This can differ from the
+	// current Terraform version of the workspace, for example when the
+	// workspace was upgraded after the run was created.
+	TerraformVersion string `jsonapi:"attr,terraform-version"`
+
 	// Relations
 	Apply                *Apply                `jsonapi:"relation,apply"`
 	ConfigurationVersion *ConfigurationVersion `jsonapi:"relation,configuration-version"`
@@ -103,6 +131,42 @@ type Run struct {
 	Workspace            *Workspace            `jsonapi:"relation,workspace"`
 }
 
+// BlockReasons summarizes why the run is waiting before it can be applied,
+// for example because a policy check soft failed and must be overridden,
+// because the cost estimation has not finished yet, or because the run must
+// be confirmed manually. It returns nil if the run
+// is not waiting for anything. Details about failed policy checks are only
+// included when the policy checks of the run are loaded.
+func (r *Run) BlockReasons() []string {
+	var reasons []string
+
+	switch r.Status {
+	case RunPolicyOverride:
+		reasons = append(reasons, "a policy check soft failed and must be overridden")
+		for _, pc := range r.PolicyChecks {
+			if pc.Status == PolicySoftFailed && pc.Result != nil {
+				reasons = append(reasons, fmt.Sprintf(
+					"policy check %s: %d soft failed policies", pc.ID, pc.Result.SoftFailed))
+			}
+		}
+
+	case RunCostEstimating:
+		reasons = append(reasons, "the cost estimation of the run has not finished")
+
+	case RunPlanned, RunCostEstimated, RunPolicyChecked:
+		if r.Actions == nil || !r.Actions.IsConfirmable {
+			return nil
+		}
+		reasons = append(reasons, "the run must be confirmed manually")
+	}
+
+	if len(reasons) > 0 && r.Permissions != nil && !r.Permissions.CanApply {
+		reasons = append(reasons, "the current user is not allowed to apply the run")
+	}
+
+	return reasons
+}
+
 // RunActions represents the run actions.
 type RunActions struct {
 	IsCancelable      bool `json:"is-cancelable"`
@@ -139,7 +203,7 @@ func (s *runs) List(ctx context.Context, workspaceID string, options RunListOpti
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/runs", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/runs", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -147,11 +211,11 @@ func (s *runs) List(ctx context.Context, workspaceID string, options RunListOpti
 
 	rl := &RunList{}
 	err = s.client.do(ctx, req, rl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return rl, nil
+	return rl, err
 }
 
 // RunCreateOptions represents the options for creating a new run.
@@ -211,7 +275,7 @@ func (s *runs) Read(ctx context.Context, runID string) (*Run, error) {
 		return nil, errors.New("invalid value for run ID")
 	}
 
-	u := fmt.Sprintf("runs/%s", url.QueryEscape(runID))
+	u := fmt.Sprintf("runs/%s", url.PathEscape(runID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -238,7 +302,7 @@ func (s *runs) Apply(ctx context.Context, runID string, options RunApplyOptions)
 		return errors.New("invalid value for run ID")
 	}
 
-	u := fmt.Sprintf("runs/%s/actions/apply", url.QueryEscape(runID))
+	u := fmt.Sprintf("runs/%s/actions/apply", url.PathEscape(runID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return err
@@ -259,7 +323,7 @@ func (s *runs) Cancel(ctx context.Context, runID string, options RunCancelOption
 		return errors.New("invalid value for run ID")
 	}
 
-	u := fmt.Sprintf("runs/%s/actions/cancel", url.QueryEscape(runID))
+	u := fmt.Sprintf("runs/%s/actions/cancel", url.PathEscape(runID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return err
@@ -280,7 +344,7 @@ func (s *runs) ForceCancel(ctx context.Context, runID string, options RunForceCa
 		return errors.New("invalid value for run ID")
 	}
 
-	u := fmt.Sprintf("runs/%s/actions/force-cancel", url.QueryEscape(runID))
+	u := fmt.Sprintf("runs/%s/actions/force-cancel", url.PathEscape(runID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return err
@@ -301,7 +365,7 @@ func (s *runs) Discard(ctx context.Context, runID string, options RunDiscardOpti
 		return errors.New("invalid value for run ID")
 	}
 
-	u := fmt.Sprintf("runs/%s/actions/discard", url.QueryEscape(runID))
+	u := fmt.Sprintf("runs/%s/actions/discard", url.PathEscape(runID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return err
@@ -309,3 +373,147 @@ func (s *runs) Discard(ctx context.Context, runID string, options RunDiscardOpti
 
 	return s.client.do(ctx, req, nil)
 }
+
+// Logs retrieves the logs of a run. The returned reader streams the plan log
+// followed by the apply log, and follows the logs until the run is finished.
+// If the run finishes without being applied, the reader ends after the plan
+// log.
+func (s *runs) Logs(ctx context.Context, runID string) (io.Reader, error) {
+	if !validStringID(&runID) {
+		return nil, errors.New("invalid value for run ID")
+	}
+
+	// Get the run to make sure it exists.
+	r, err := s.Read(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Plan == nil {
+		return nil, fmt.Errorf("run %s does not have a plan", runID)
+	}
+
+	planLogs, err := s.client.Plans.Logs(ctx, r.Plan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	applyLogs := &lazyReader{
+		open: func() (io.Reader, error) {
+			return s.applyLogs(ctx, runID)
+		},
+	}
+
+	return io.MultiReader(planLogs, applyLogs), nil
+}
+
+// applyLogs waits until the apply of the given run is started, and then
+// returns its logs. If the run finishes without being applied, it returns
+// an empty reader.
+func (s *runs) applyLogs(ctx context.Context, runID string) (io.Reader, error) {
+	for i := 1; ; i++ {
+		r, err := s.Read(ctx, runID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch r.Status {
+		case RunConfirmed, RunApplying, RunApplied:
+			if r.Apply == nil {
+				return nil, fmt.Errorf("run %s does not have an apply", runID)
+			}
+			return s.client.Applies.Logs(ctx, r.Apply.ID)
+
+		case RunCanceled, RunDiscarded, RunErrored, RunPlannedAndFinished:
+			if r.Apply == nil {
+				return strings.NewReader(""), nil
+			}
+
+			// The run may have been canceled or errored during the
+			// apply, in which case we still want the apply logs.
+			a, err := s.client.Applies.Read(ctx, r.Apply.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			switch a.Status {
+			case ApplyCanceled, ApplyErrored, ApplyFinished:
+				return s.client.Applies.Logs(ctx, a.ID)
+			default:
+				return strings.NewReader(""), nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff(500, 2000, i)):
+		}
+	}
+}
+
+// TaskStages returns all the task stages of a run, for example the pre-plan
+// and post-plan stages, including the results of the run tasks executed
+// within each stage.
+func (s *runs) TaskStages(ctx context.Context, runID string) ([]*TaskStage, error) {
+	if !validStringID(&runID) {
+		return nil, errors.New("invalid value for run ID")
+	}
+
+	u := fmt.Sprintf("runs/%s/task-stages", url.PathEscape(runID))
+
+	var stages []*TaskStage
+	options := taskStageListOptions{Include: "task_results"}
+	for {
+		req, err := s.client.newRequest("GET", u, &options)
+		if err != nil {
+			return nil, err
+		}
+
+		tsl := &taskStageList{}
+		err = s.client.do(ctx, req, tsl)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, tsl.Items...)
+
+		if tsl.Pagination == nil || tsl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = tsl.NextPage
+	}
+
+	// Task results that were not included in the response only
+	// contain their ID, so read those one by one.
+	for _, stage := range stages {
+		for i, tr := range stage.TaskResults {
+			if tr.Status != "" {
+				continue
+			}
+			result, err := s.readTaskResult(ctx, tr.ID)
+			if err != nil {
+				return nil, err
+			}
+			stage.TaskResults[i] = result
+		}
+	}
+
+	return stages, nil
+}
+
+// readTaskResult reads a task result by its ID.
+func (s *runs) readTaskResult(ctx context.Context, taskResultID string) (*TaskResult, error) {
+	u := fmt.Sprintf("task-results/%s", url.PathEscape(taskResultID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &TaskResult{}
+	err = s.client.do(ctx, req, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
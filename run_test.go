@@ -2,6 +2,10 @@ package tfe
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -119,6 +123,12 @@ func TestRunsRead(t *testing.T) {
 		assert.Equal(t, rTest, r)
 	})
 
+	t.Run("includes the Terraform version used", func(t *testing.T) {
+		r, err := client.Runs.Read(ctx, rTest.ID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, r.TerraformVersion)
+	})
+
 	t.Run("when the run does not exist", func(t *testing.T) {
 		r, err := client.Runs.Read(ctx, "nonexisting")
 		assert.Nil(t, r)
@@ -275,3 +285,199 @@ func TestRunsDiscard(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for run ID")
 	})
 }
+
+func TestRunsLogs(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("when the run is applied", func(t *testing.T) {
+		rTest, rTestCleanup := createAppliedRun(t, client, nil)
+		defer rTestCleanup()
+
+		logReader, err := client.Runs.Logs(ctx, rTest.ID)
+		require.NoError(t, err)
+
+		logs, err := ioutil.ReadAll(logReader)
+		require.NoError(t, err)
+
+		plan := strings.Index(string(logs), "1 to add, 0 to change, 0 to destroy")
+		apply := strings.Index(string(logs), "Apply complete!")
+		assert.True(t, plan >= 0, "expected the plan log")
+		assert.True(t, apply > plan, "expected the apply log after the plan log")
+	})
+
+	t.Run("when the run is discarded", func(t *testing.T) {
+		rTest, rTestCleanup := createPlannedRun(t, client, nil)
+		defer rTestCleanup()
+
+		err := client.Runs.Discard(ctx, rTest.ID, RunDiscardOptions{})
+		require.NoError(t, err)
+
+		logReader, err := client.Runs.Logs(ctx, rTest.ID)
+		require.NoError(t, err)
+
+		logs, err := ioutil.ReadAll(logReader)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(logs), "1 to add, 0 to change, 0 to destroy")
+		assert.NotContains(t, string(logs), "Apply complete!")
+	})
+
+	t.Run("when the run does not exist", func(t *testing.T) {
+		logs, err := client.Runs.Logs(ctx, "nonexisting")
+		assert.Nil(t, logs)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		logs, err := client.Runs.Logs(ctx, badIdentifier)
+		assert.Nil(t, logs)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunsApplyLogs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(200)
+		w.Write([]byte(`{"data": {"type": "runs", "id": "run-123", "attributes": {"status": "applied"}}}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	t.Run("when the run does not have an apply", func(t *testing.T) {
+		logs, err := client.Runs.(*runs).applyLogs(context.Background(), "run-123")
+		assert.Nil(t, logs)
+		assert.EqualError(t, err, "run run-123 does not have an apply")
+	})
+}
+
+func TestRunsTaskStages(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	rTest, rTestCleanup := createRun(t, client, nil)
+	defer rTestCleanup()
+
+	t.Run("when the run exists", func(t *testing.T) {
+		stages, err := client.Runs.TaskStages(ctx, rTest.ID)
+		require.NoError(t, err)
+
+		for _, stage := range stages {
+			assert.NotEmpty(t, stage.ID)
+			assert.NotEmpty(t, stage.Stage)
+			assert.NotEmpty(t, stage.Status)
+			for _, tr := range stage.TaskResults {
+				assert.NotEmpty(t, tr.Status)
+			}
+		}
+	})
+
+	t.Run("when the run does not exist", func(t *testing.T) {
+		stages, err := client.Runs.TaskStages(ctx, "nonexisting")
+		assert.Nil(t, stages)
+		assert.Equal(t, err, ErrResourceNotFound)
+	})
+
+	t.Run("with invalid run ID", func(t *testing.T) {
+		stages, err := client.Runs.TaskStages(ctx, badIdentifier)
+		assert.Nil(t, stages)
+		assert.EqualError(t, err, "invalid value for run ID")
+	})
+}
+
+func TestRunBlockReasons(t *testing.T) {
+	t.Run("when the run must be confirmed", func(t *testing.T) {
+		r := &Run{
+			Status:      RunPlanned,
+			Actions:     &RunActions{IsConfirmable: true},
+			Permissions: &RunPermissions{CanApply: true},
+		}
+		assert.Equal(t, []string{"the run must be confirmed manually"}, r.BlockReasons())
+	})
+
+	t.Run("when the run is waiting on the cost estimation", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			run    *Run
+			reason []string
+		}{
+			{
+				name:   "while estimating",
+				run:    &Run{Status: RunCostEstimating},
+				reason: []string{"the cost estimation of the run has not finished"},
+			},
+			{
+				name: "when estimated and confirmable",
+				run: &Run{
+					Status:      RunCostEstimated,
+					Actions:     &RunActions{IsConfirmable: true},
+					Permissions: &RunPermissions{CanApply: true},
+				},
+				reason: []string{"the run must be confirmed manually"},
+			},
+			{
+				name: "when estimated without permission to apply",
+				run: &Run{
+					Status:      RunCostEstimated,
+					Actions:     &RunActions{IsConfirmable: true},
+					Permissions: &RunPermissions{CanApply: false},
+				},
+				reason: []string{
+					"the run must be confirmed manually",
+					"the current user is not allowed to apply the run",
+				},
+			},
+			{
+				name: "when estimated and not confirmable",
+				run: &Run{
+					Status:  RunCostEstimated,
+					Actions: &RunActions{IsConfirmable: false},
+				},
+				reason: nil,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.reason, tt.run.BlockReasons())
+			})
+		}
+	})
+
+	t.Run("when a policy check soft failed", func(t *testing.T) {
+		r := &Run{
+			Status:      RunPolicyOverride,
+			Permissions: &RunPermissions{CanApply: false},
+			PolicyChecks: []*PolicyCheck{
+				{ID: "polchk-1", Status: PolicyPasses, Result: &PolicyResult{Passed: 2}},
+				{ID: "polchk-2", Status: PolicySoftFailed, Result: &PolicyResult{SoftFailed: 1}},
+			},
+		}
+		assert.Equal(t, []string{
+			"a policy check soft failed and must be overridden",
+			"policy check polchk-2: 1 soft failed policies",
+			"the current user is not allowed to apply the run",
+		}, r.BlockReasons())
+	})
+
+	t.Run("when the run is not blocked", func(t *testing.T) {
+		for _, r := range []*Run{
+			{Status: RunApplying},
+			{Status: RunPlanned, Actions: &RunActions{IsConfirmable: false}},
+			{Status: RunPlannedAndFinished, Permissions: &RunPermissions{CanApply: false}},
+		} {
+			assert.Nil(t, r.BlockReasons())
+		}
+	})
+}
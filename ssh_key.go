@@ -60,7 +60,7 @@ func (s *sshKeys) List(ctx context.Context, organization string, options SSHKeyL
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/ssh-keys", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/ssh-keys", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -68,11 +68,11 @@ func (s *sshKeys) List(ctx context.Context, organization string, options SSHKeyL
 
 	kl := &SSHKeyList{}
 	err = s.client.do(ctx, req, kl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return kl, nil
+	return kl, err
 }
 
 // SSHKeyCreateOptions represents the options for creating an SSH key.
@@ -110,7 +110,7 @@ func (s *sshKeys) Create(ctx context.Context, organization string, options SSHKe
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s/ssh-keys", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/ssh-keys", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -131,7 +131,7 @@ func (s *sshKeys) Read(ctx context.Context, sshKeyID string) (*SSHKey, error) {
 		return nil, errors.New("invalid value for SSH key ID")
 	}
 
-	u := fmt.Sprintf("ssh-keys/%s", url.QueryEscape(sshKeyID))
+	u := fmt.Sprintf("ssh-keys/%s", url.PathEscape(sshKeyID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -167,7 +167,7 @@ func (s *sshKeys) Update(ctx context.Context, sshKeyID string, options SSHKeyUpd
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("ssh-keys/%s", url.QueryEscape(sshKeyID))
+	u := fmt.Sprintf("ssh-keys/%s", url.PathEscape(sshKeyID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -188,7 +188,7 @@ func (s *sshKeys) Delete(ctx context.Context, sshKeyID string) error {
 		return errors.New("invalid value for SSH key ID")
 	}
 
-	u := fmt.Sprintf("ssh-keys/%s", url.QueryEscape(sshKeyID))
+	u := fmt.Sprintf("ssh-keys/%s", url.PathEscape(sshKeyID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
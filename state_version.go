@@ -58,6 +58,17 @@ type StateVersion struct {
 	Run *Run `jsonapi:"relation,run"`
 }
 
+// StateVersionOutput represents a single output of a state version.
+type StateVersionOutput struct {
+	ID        string `jsonapi:"primary,state-version-outputs"`
+	Name      string `jsonapi:"attr,name"`
+	Sensitive bool   `jsonapi:"attr,sensitive"`
+	Type      string `jsonapi:"attr,type"`
+
+	// The value of the output. This is always nil for sensitive outputs.
+	Value interface{}
+}
+
 // StateVersionListOptions represents the options for listing state versions.
 type StateVersionListOptions struct {
 	ListOptions
@@ -88,11 +99,11 @@ func (s *stateVersions) List(ctx context.Context, options StateVersionListOption
 
 	svl := &StateVersionList{}
 	err = s.client.do(ctx, req, svl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return svl, nil
+	return svl, err
 }
 
 // StateVersionCreateOptions represents the options for creating a state version.
@@ -141,7 +152,7 @@ func (s *stateVersions) Create(ctx context.Context, workspaceID string, options
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("workspaces/%s/state-versions", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/state-versions", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -162,7 +173,7 @@ func (s *stateVersions) Read(ctx context.Context, svID string) (*StateVersion, e
 		return nil, errors.New("invalid value for state version ID")
 	}
 
-	u := fmt.Sprintf("state-versions/%s", url.QueryEscape(svID))
+	u := fmt.Sprintf("state-versions/%s", url.PathEscape(svID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -183,7 +194,7 @@ func (s *stateVersions) Current(ctx context.Context, workspaceID string) (*State
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/current-state-version", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/current-state-version", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -207,7 +218,7 @@ func (s *stateVersions) Download(ctx context.Context, url string) ([]byte, error
 	req.Header.Set("Accept", "application/json")
 
 	var buf bytes.Buffer
-	err = s.client.do(ctx, req, &buf)
+	err = s.client.do(withDownload(ctx), req, &buf)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,98 @@
+package tfe
+
+import (
+	"time"
+)
+
+// Stage represents the point of a run at which run tasks are executed.
+type Stage string
+
+// List all available stages.
+const (
+	PrePlan  Stage = "pre_plan"
+	PostPlan Stage = "post_plan"
+)
+
+// TaskStageStatus represents the status of a task stage.
+type TaskStageStatus string
+
+// List all available task stage statuses.
+const (
+	TaskStagePending          TaskStageStatus = "pending"
+	TaskStageRunning          TaskStageStatus = "running"
+	TaskStagePassed           TaskStageStatus = "passed"
+	TaskStageFailed           TaskStageStatus = "failed"
+	TaskStageAwaitingOverride TaskStageStatus = "awaiting_override"
+	TaskStageCanceled         TaskStageStatus = "canceled"
+	TaskStageErrored          TaskStageStatus = "errored"
+	TaskStageUnreachable      TaskStageStatus = "unreachable"
+)
+
+// TaskResultStatus represents the status of a task result.
+type TaskResultStatus string
+
+// List all available task result statuses.
+const (
+	TaskResultPassed      TaskResultStatus = "passed"
+	TaskResultFailed      TaskResultStatus = "failed"
+	TaskResultPending     TaskResultStatus = "pending"
+	TaskResultRunning     TaskResultStatus = "running"
+	TaskResultUnreachable TaskResultStatus = "unreachable"
+	TaskResultErrored     TaskResultStatus = "errored"
+)
+
+// TaskStage represents a stage of a run at which run tasks are executed,
+// together with the results of those tasks.
+type TaskStage struct {
+	ID               string                     `jsonapi:"primary,task-stages"`
+	CreatedAt        time.Time                  `jsonapi:"attr,created-at,iso8601"`
+	Stage            Stage                      `jsonapi:"attr,stage"`
+	Status           TaskStageStatus            `jsonapi:"attr,status"`
+	StatusTimestamps *TaskStageStatusTimestamps `jsonapi:"attr,status-timestamps"`
+	UpdatedAt        time.Time                  `jsonapi:"attr,updated-at,iso8601"`
+
+	// Relations
+	Run         *Run          `jsonapi:"relation,run"`
+	TaskResults []*TaskResult `jsonapi:"relation,task-results"`
+}
+
+// TaskStageStatusTimestamps holds the timestamps for individual task stage
+// statuses.
+type TaskStageStatusTimestamps struct {
+	ErroredAt  time.Time `json:"errored-at"`
+	PassedAt   time.Time `json:"passed-at"`
+	RunningAt  time.Time `json:"running-at"`
+	CanceledAt time.Time `json:"canceled-at"`
+	FailedAt   time.Time `json:"failed-at"`
+}
+
+// TaskResult represents the result of a single run task within a task
+// stage.
+type TaskResult struct {
+	ID               string           `jsonapi:"primary,task-results"`
+	CreatedAt        time.Time        `jsonapi:"attr,created-at,iso8601"`
+	EnforcementLevel string           `jsonapi:"attr,workspace-task-enforcement-level"`
+	Message          string           `jsonapi:"attr,message"`
+	Status           TaskResultStatus `jsonapi:"attr,status"`
+	TaskName         string           `jsonapi:"attr,task-name"`
+	TaskURL          string           `jsonapi:"attr,task-url"`
+	UpdatedAt        time.Time        `jsonapi:"attr,updated-at,iso8601"`
+	URL              string           `jsonapi:"attr,url"`
+
+	// Relations
+	TaskStage *TaskStage `jsonapi:"relation,task-stage"`
+}
+
+// taskStageList represents a list of task stages.
+type taskStageList struct {
+	*Pagination
+	Items []*TaskStage
+}
+
+// taskStageListOptions represents the options for listing task stages.
+type taskStageListOptions struct {
+	ListOptions
+
+	// A comma-separated list of related resources to include.
+	Include string `url:"include,omitempty"`
+}
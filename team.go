@@ -78,7 +78,7 @@ func (s *teams) List(ctx context.Context, organization string, options TeamListO
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/teams", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/teams", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -86,11 +86,11 @@ func (s *teams) List(ctx context.Context, organization string, options TeamListO
 
 	tl := &TeamList{}
 	err = s.client.do(ctx, req, tl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return tl, nil
+	return tl, err
 }
 
 // TeamCreateOptions represents the options for creating a team.
@@ -131,7 +131,7 @@ func (s *teams) Create(ctx context.Context, organization string, options TeamCre
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s/teams", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/teams", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -152,7 +152,7 @@ func (s *teams) Read(ctx context.Context, teamID string) (*Team, error) {
 		return nil, errors.New("invalid value for team ID")
 	}
 
-	u := fmt.Sprintf("teams/%s", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s", url.PathEscape(teamID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -188,7 +188,7 @@ func (s *teams) Update(ctx context.Context, teamID string, options TeamUpdateOpt
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("teams/%s", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s", url.PathEscape(teamID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -209,7 +209,7 @@ func (s *teams) Delete(ctx context.Context, teamID string) error {
 		return errors.New("invalid value for team ID")
 	}
 
-	u := fmt.Sprintf("teams/%s", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s", url.PathEscape(teamID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
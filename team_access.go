@@ -65,6 +65,11 @@ type TeamAccess struct {
 type TeamAccessListOptions struct {
 	ListOptions
 	WorkspaceID *string `url:"filter[workspace][id],omitempty"`
+
+	// A comma-separated list of related resources to include. Use "team"
+	// to include the team of each team access, so that its details are
+	// available without reading each team separately.
+	Include *string `url:"include,omitempty"`
 }
 
 func (o TeamAccessListOptions) valid() error {
@@ -90,11 +95,11 @@ func (s *teamAccesses) List(ctx context.Context, options TeamAccessListOptions)
 
 	tal := &TeamAccessList{}
 	err = s.client.do(ctx, req, tal)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return tal, nil
+	return tal, err
 }
 
 // TeamAccessAddOptions represents the options for adding team access.
@@ -154,7 +159,7 @@ func (s *teamAccesses) Read(ctx context.Context, teamAccessID string) (*TeamAcce
 		return nil, errors.New("invalid value for team access ID")
 	}
 
-	u := fmt.Sprintf("team-workspaces/%s", url.QueryEscape(teamAccessID))
+	u := fmt.Sprintf("team-workspaces/%s", url.PathEscape(teamAccessID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -175,7 +180,7 @@ func (s *teamAccesses) Remove(ctx context.Context, teamAccessID string) error {
 		return errors.New("invalid value for team access ID")
 	}
 
-	u := fmt.Sprintf("team-workspaces/%s", url.QueryEscape(teamAccessID))
+	u := fmt.Sprintf("team-workspaces/%s", url.PathEscape(teamAccessID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -16,7 +16,7 @@ var _ TeamMembers = (*teamMembers)(nil)
 // TFE API docs:
 // https://www.terraform.io/docs/enterprise/api/team-members.html
 type TeamMembers interface {
-	// List all members of a team.
+	// List all members of a team, including their usernames and emails.
 	List(ctx context.Context, teamID string) ([]*User, error)
 
 	// Add multiple users to a team.
@@ -35,7 +35,9 @@ type teamMember struct {
 	Username string `jsonapi:"primary,users"`
 }
 
-// List all members of a team.
+// List all members of a team. The members are included when reading the team,
+// so the complete list of users (with their usernames and emails) is returned
+// at once and there is no need to paginate.
 func (s *teamMembers) List(ctx context.Context, teamID string) ([]*User, error) {
 	if !validStringID(&teamID) {
 		return nil, errors.New("invalid value for team ID")
@@ -47,7 +49,7 @@ func (s *teamMembers) List(ctx context.Context, teamID string) ([]*User, error)
 		Include: "users",
 	}
 
-	u := fmt.Sprintf("teams/%s", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s", url.PathEscape(teamID))
 	req, err := s.client.newRequest("GET", u, options)
 	if err != nil {
 		return nil, err
@@ -91,7 +93,7 @@ func (s *teamMembers) Add(ctx context.Context, teamID string, options TeamMember
 		tms = append(tms, &teamMember{Username: name})
 	}
 
-	u := fmt.Sprintf("teams/%s/relationships/users", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s/relationships/users", url.PathEscape(teamID))
 	req, err := s.client.newRequest("POST", u, tms)
 	if err != nil {
 		return err
@@ -129,7 +131,7 @@ func (s *teamMembers) Remove(ctx context.Context, teamID string, options TeamMem
 		tms = append(tms, &teamMember{Username: name})
 	}
 
-	u := fmt.Sprintf("teams/%s/relationships/users", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s/relationships/users", url.PathEscape(teamID))
 	req, err := s.client.newRequest("DELETE", u, tms)
 	if err != nil {
 		return err
@@ -29,6 +29,8 @@ func TestTeamMembersList(t *testing.T) {
 		found := false
 		for _, user := range users {
 			if user.Username == "admin" {
+				assert.NotEmpty(t, user.ID)
+				assert.NotEmpty(t, user.Email)
 				found = true
 				break
 			}
@@ -0,0 +1,250 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Compile-time proof of interface implementation.
+var _ TeamProjectAccesses = (*teamProjectAccesses)(nil)
+
+// TeamProjectAccesses describes all the team project access related methods
+// that the Terraform Enterprise API supports.
+//
+// TFE API docs:
+// https://www.terraform.io/docs/cloud/api/team-projects.html
+type TeamProjectAccesses interface {
+	// List all the team accesses for a given project.
+	List(ctx context.Context, options TeamProjectAccessListOptions) (*TeamProjectAccessList, error)
+
+	// Add team access for a project.
+	Add(ctx context.Context, options TeamProjectAccessAddOptions) (*TeamProjectAccess, error)
+
+	// Read a team project access by its ID.
+	Read(ctx context.Context, teamProjectAccessID string) (*TeamProjectAccess, error)
+
+	// Update a team project access by its ID.
+	Update(ctx context.Context, teamProjectAccessID string, options TeamProjectAccessUpdateOptions) (*TeamProjectAccess, error)
+
+	// Remove team access from a project.
+	Remove(ctx context.Context, teamProjectAccessID string) error
+}
+
+// teamProjectAccesses implements TeamProjectAccesses.
+type teamProjectAccesses struct {
+	client *Client
+}
+
+// TeamProjectAccessType represents a team project access type.
+type TeamProjectAccessType string
+
+// List all available team project access types.
+const (
+	TeamProjectAccessAdmin    TeamProjectAccessType = "admin"
+	TeamProjectAccessMaintain TeamProjectAccessType = "maintain"
+	TeamProjectAccessWrite    TeamProjectAccessType = "write"
+	TeamProjectAccessRead     TeamProjectAccessType = "read"
+	TeamProjectAccessCustom   TeamProjectAccessType = "custom"
+)
+
+// TeamProjectAccessList represents a list of team project accesses.
+type TeamProjectAccessList struct {
+	*Pagination
+	Items []*TeamProjectAccess
+}
+
+// TeamProjectAccess represents the project access for a team.
+type TeamProjectAccess struct {
+	ID     string                `jsonapi:"primary,team-projects"`
+	Access TeamProjectAccessType `jsonapi:"attr,access"`
+
+	// Relations
+	Team    *Team    `jsonapi:"relation,team"`
+	Project *Project `jsonapi:"relation,project"`
+}
+
+// TeamProjectAccessListOptions represents the options for listing team
+// project accesses.
+type TeamProjectAccessListOptions struct {
+	ListOptions
+	ProjectID *string `url:"filter[project][id],omitempty"`
+}
+
+func (o TeamProjectAccessListOptions) valid() error {
+	if !validString(o.ProjectID) {
+		return errors.New("project ID is required")
+	}
+	if !validStringID(o.ProjectID) {
+		return errors.New("invalid value for project ID")
+	}
+	return nil
+}
+
+// List all the team accesses for a given project.
+func (s *teamProjectAccesses) List(ctx context.Context, options TeamProjectAccessListOptions) (*TeamProjectAccessList, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest("GET", "team-projects", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	tpal := &TeamProjectAccessList{}
+	err = s.client.do(ctx, req, tpal)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	return tpal, err
+}
+
+// TeamProjectAccessAddOptions represents the options for adding team access
+// to a project.
+type TeamProjectAccessAddOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,team-projects"`
+
+	// The type of access to grant.
+	Access *TeamProjectAccessType `jsonapi:"attr,access"`
+
+	// The team to add to the project.
+	Team *Team `jsonapi:"relation,team"`
+
+	// The project to which the team is to be added.
+	Project *Project `jsonapi:"relation,project"`
+}
+
+func (o TeamProjectAccessAddOptions) valid() error {
+	if o.Access == nil {
+		return errors.New("access is required")
+	}
+	if !validTeamProjectAccess(*o.Access) {
+		return errors.New("invalid value for access")
+	}
+	if o.Team == nil {
+		return errors.New("team is required")
+	}
+	if o.Project == nil {
+		return errors.New("project is required")
+	}
+	return nil
+}
+
+// Add team access for a project.
+func (s *teamProjectAccesses) Add(ctx context.Context, options TeamProjectAccessAddOptions) (*TeamProjectAccess, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	req, err := s.client.newRequest("POST", "team-projects", &options)
+	if err != nil {
+		return nil, err
+	}
+
+	tpa := &TeamProjectAccess{}
+	err = s.client.do(ctx, req, tpa)
+	if err != nil {
+		return nil, err
+	}
+
+	return tpa, nil
+}
+
+// Read a team project access by its ID.
+func (s *teamProjectAccesses) Read(ctx context.Context, teamProjectAccessID string) (*TeamProjectAccess, error) {
+	if !validStringID(&teamProjectAccessID) {
+		return nil, errors.New("invalid value for team project access ID")
+	}
+
+	u := fmt.Sprintf("team-projects/%s", url.PathEscape(teamProjectAccessID))
+	req, err := s.client.newRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tpa := &TeamProjectAccess{}
+	err = s.client.do(ctx, req, tpa)
+	if err != nil {
+		return nil, err
+	}
+
+	return tpa, nil
+}
+
+// TeamProjectAccessUpdateOptions represents the options for updating a team
+// project access.
+type TeamProjectAccessUpdateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,team-projects"`
+
+	// The type of access to grant.
+	Access *TeamProjectAccessType `jsonapi:"attr,access,omitempty"`
+}
+
+func (o TeamProjectAccessUpdateOptions) valid() error {
+	if o.Access != nil && !validTeamProjectAccess(*o.Access) {
+		return errors.New("invalid value for access")
+	}
+	return nil
+}
+
+// Update a team project access by its ID.
+func (s *teamProjectAccesses) Update(ctx context.Context, teamProjectAccessID string, options TeamProjectAccessUpdateOptions) (*TeamProjectAccess, error) {
+	if !validStringID(&teamProjectAccessID) {
+		return nil, errors.New("invalid value for team project access ID")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("team-projects/%s", url.PathEscape(teamProjectAccessID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	tpa := &TeamProjectAccess{}
+	err = s.client.do(ctx, req, tpa)
+	if err != nil {
+		return nil, err
+	}
+
+	return tpa, nil
+}
+
+// Remove team access from a project.
+func (s *teamProjectAccesses) Remove(ctx context.Context, teamProjectAccessID string) error {
+	if !validStringID(&teamProjectAccessID) {
+		return errors.New("invalid value for team project access ID")
+	}
+
+	u := fmt.Sprintf("team-projects/%s", url.PathEscape(teamProjectAccessID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+func validTeamProjectAccess(v TeamProjectAccessType) bool {
+	switch v {
+	case TeamProjectAccessAdmin,
+		TeamProjectAccessMaintain,
+		TeamProjectAccessWrite,
+		TeamProjectAccessRead,
+		TeamProjectAccessCustom:
+		return true
+	}
+	return false
+}
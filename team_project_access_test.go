@@ -0,0 +1,114 @@
+package tfe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamProjectAccessesList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("without project ID", func(t *testing.T) {
+		tpal, err := client.TeamProjectAccess.List(ctx, TeamProjectAccessListOptions{})
+		assert.Nil(t, tpal)
+		assert.EqualError(t, err, "project ID is required")
+	})
+
+	t.Run("with an invalid project ID", func(t *testing.T) {
+		tpal, err := client.TeamProjectAccess.List(ctx, TeamProjectAccessListOptions{
+			ProjectID: String(badIdentifier),
+		})
+		assert.Nil(t, tpal)
+		assert.EqualError(t, err, "invalid value for project ID")
+	})
+}
+
+func TestTeamProjectAccessesAdd(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	tmTest := &Team{ID: "team-123"}
+	pTest := &Project{ID: "prj-123"}
+
+	t.Run("when options is missing access", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Add(ctx, TeamProjectAccessAddOptions{
+			Team:    tmTest,
+			Project: pTest,
+		})
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "access is required")
+	})
+
+	t.Run("when options has an invalid access", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Add(ctx, TeamProjectAccessAddOptions{
+			Access:  ProjectAccess("plan"),
+			Team:    tmTest,
+			Project: pTest,
+		})
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "invalid value for access")
+	})
+
+	t.Run("when options is missing team", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Add(ctx, TeamProjectAccessAddOptions{
+			Access:  ProjectAccess(TeamProjectAccessRead),
+			Project: pTest,
+		})
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "team is required")
+	})
+
+	t.Run("when options is missing project", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Add(ctx, TeamProjectAccessAddOptions{
+			Access: ProjectAccess(TeamProjectAccessRead),
+			Team:   tmTest,
+		})
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "project is required")
+	})
+}
+
+func TestTeamProjectAccessesRead(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("without a valid team project access ID", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Read(ctx, badIdentifier)
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "invalid value for team project access ID")
+	})
+}
+
+func TestTeamProjectAccessesUpdate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("without a valid team project access ID", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Update(ctx, badIdentifier, TeamProjectAccessUpdateOptions{
+			Access: ProjectAccess(TeamProjectAccessWrite),
+		})
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "invalid value for team project access ID")
+	})
+
+	t.Run("with an invalid access", func(t *testing.T) {
+		tpa, err := client.TeamProjectAccess.Update(ctx, "tprj-123", TeamProjectAccessUpdateOptions{
+			Access: ProjectAccess("plan"),
+		})
+		assert.Nil(t, tpa)
+		assert.EqualError(t, err, "invalid value for access")
+	})
+}
+
+func TestTeamProjectAccessesRemove(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	t.Run("without a valid team project access ID", func(t *testing.T) {
+		err := client.TeamProjectAccess.Remove(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for team project access ID")
+	})
+}
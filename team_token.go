@@ -47,7 +47,7 @@ func (s *teamTokens) Generate(ctx context.Context, teamID string) (*TeamToken, e
 		return nil, errors.New("invalid value for team ID")
 	}
 
-	u := fmt.Sprintf("teams/%s/authentication-token", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s/authentication-token", url.PathEscape(teamID))
 	req, err := s.client.newRequest("POST", u, nil)
 	if err != nil {
 		return nil, err
@@ -68,7 +68,7 @@ func (s *teamTokens) Read(ctx context.Context, teamID string) (*TeamToken, error
 		return nil, errors.New("invalid value for team ID")
 	}
 
-	u := fmt.Sprintf("teams/%s/authentication-token", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s/authentication-token", url.PathEscape(teamID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -89,7 +89,7 @@ func (s *teamTokens) Delete(ctx context.Context, teamID string) error {
 		return errors.New("invalid value for team ID")
 	}
 
-	u := fmt.Sprintf("teams/%s/authentication-token", url.QueryEscape(teamID))
+	u := fmt.Sprintf("teams/%s/authentication-token", url.PathEscape(teamID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/url"
@@ -14,6 +15,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -44,11 +47,26 @@ var (
 	// ErrWorkspaceNotLocked is returned when trying to unlock
 	// a unlocked workspace.
 	ErrWorkspaceNotLocked = errors.New("workspace already unlocked")
+	// ErrWorkspaceGlobalRemoteState is returned when trying to safely
+	// delete a workspace that shares its state with the organization.
+	ErrWorkspaceGlobalRemoteState = errors.New("workspace state is shared with the organization")
 
 	// ErrUnauthorized is returned when a receiving a 401.
 	ErrUnauthorized = errors.New("unauthorized")
 	// ErrResourceNotFound is returned when a receiving a 404.
 	ErrResourceNotFound = errors.New("resource not found")
+
+	// ErrClientClosed is returned when using a closed client.
+	ErrClientClosed = errors.New("client closed")
+
+	// ErrResponseTooLarge is returned when a response body exceeds
+	// the configured MaxResponseBytes.
+	ErrResponseTooLarge = errors.New("response too large")
+
+	// ErrDestructiveNotConfirmed is returned by destructive bulk helpers
+	// when Config.ConfirmDestructive is enabled and the operation was not
+	// confirmed using WithDestructiveConfirmed.
+	ErrDestructiveNotConfirmed = errors.New("destructive operation not confirmed")
 )
 
 // Config provides configuration details to the API client.
@@ -67,6 +85,47 @@ type Config struct {
 
 	// A custom HTTP client to use.
 	HTTPClient *http.Client
+
+	// TolerateParseErrors makes List methods skip list items that cannot be
+	// parsed instead of failing the whole call. The successfully parsed items
+	// are returned together with a *ParseError describing the skipped items.
+	TolerateParseErrors bool
+
+	// Logger is used to log warnings, for example when using a deprecated
+	// API endpoint. Nothing is logged when no logger is configured.
+	Logger Logger
+
+	// MaxResponseBytes limits the size of the response bodies that are
+	// decoded by the client. Responses that exceed the limit fail with
+	// ErrResponseTooLarge. Downloads of state and policies, and log
+	// streams, are not limited. Zero means unlimited.
+	MaxResponseBytes int64
+
+	// RequestSigner is called for every request right before it is sent,
+	// after all headers and the final body are set. It can be used to add
+	// signature headers, for example when routing requests through a
+	// signing proxy. The request body can be read by the signer and will
+	// be restored before sending. If it returns an error, the request is
+	// not sent and the error is returned.
+	RequestSigner func(*http.Request) error
+
+	// CaptureFailedRequests wraps the errors returned for failed requests
+	// in a *CapturedRequestError holding a redacted copy of the request,
+	// which can be sent again using Client.Replay.
+	CaptureFailedRequests bool
+
+	// OnRetry is called before each retry of a request, with the number of
+	// the retry (starting at 1) and the response or error that caused it.
+	// Returning false stops retrying, in which case the last response or
+	// error is returned. When nil, requests are retried as usual.
+	OnRetry func(attempt int, resp *http.Response, err error) (retry bool)
+
+	// ConfirmDestructive requires destructive bulk helpers, like removing
+	// tags from many workspaces or canceling all pending runs, to be called
+	// with a context returned by WithDestructiveConfirmed. Otherwise they
+	// fail with ErrDestructiveNotConfirmed. Deleting a single resource is
+	// not affected.
+	ConfirmDestructive bool
 }
 
 // DefaultConfig returns a default config structure.
@@ -90,6 +149,71 @@ func DefaultConfig() *Config {
 	return config
 }
 
+// Logger is the interface used by the client to log warnings. It is
+// implemented by *log.Logger.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// RequestPriority is a scheduling hint used by the client's rate limiter.
+type RequestPriority int
+
+// List all available request priorities.
+const (
+	// PriorityNormal is the default priority of all requests.
+	PriorityNormal RequestPriority = iota
+
+	// PriorityHigh requests are handed rate limiter tokens before any
+	// normal priority requests that are still waiting to be scheduled.
+	PriorityHigh
+)
+
+// priorityKey is the context key used to store the request priority.
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx carrying the given request priority.
+// Any request made with the returned context will be scheduled according
+// to that priority when the client is being rate limited.
+//
+// While one or more high priority requests are waiting for the rate limiter,
+// normal priority requests will hold back and not claim any new tokens. This
+// keeps latency sensitive (interactive) reads responsive while a large batch
+// of background requests is being processed. Normal priority requests that
+// already claimed a token keep their place in line.
+func WithPriority(ctx context.Context, priority RequestPriority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// priorityFromContext returns the request priority stored in ctx, or
+// PriorityNormal if no priority was set.
+func priorityFromContext(ctx context.Context) RequestPriority {
+	if p, ok := ctx.Value(priorityKey{}).(RequestPriority); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// CorrelationIDHeader is the request header used to send the correlation ID
+// stored in the request context.
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// correlationIDKey is the context key used to store the correlation ID.
+type correlationIDKey struct{}
+
+// WithCorrelationID returns a copy of ctx carrying the given correlation ID.
+// Any request made with the returned context sends the ID in the
+// CorrelationIDHeader header, and errors returned for those requests are
+// wrapped in a *CorrelationError so they can be tied to the request.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID stored in ctx, if any.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok && id != ""
+}
+
 // Client is the Terraform Enterprise API client. It provides the basic
 // connectivity and configuration for accessing the TFE API.
 type Client struct {
@@ -99,26 +223,60 @@ type Client struct {
 	http    *retryablehttp.Client
 	limiter *rate.Limiter
 
-	Applies               Applies
-	ConfigurationVersions ConfigurationVersions
-	OAuthClients          OAuthClients
-	OAuthTokens           OAuthTokens
-	Organizations         Organizations
-	OrganizationTokens    OrganizationTokens
-	Plans                 Plans
-	Policies              Policies
-	PolicyChecks          PolicyChecks
-	PolicySets            PolicySets
-	Runs                  Runs
-	SSHKeys               SSHKeys
-	StateVersions         StateVersions
-	Teams                 Teams
-	TeamAccess            TeamAccesses
-	TeamMembers           TeamMembers
-	TeamTokens            TeamTokens
-	Users                 Users
-	Variables             Variables
-	Workspaces            Workspaces
+	// The number of high priority requests waiting for the limiter.
+	prioritized int32
+
+	tolerateParseErrors bool
+
+	// The maximum size of decoded response bodies, or 0 if unlimited.
+	maxResponseBytes int64
+
+	// The configured logger, if any.
+	logger Logger
+
+	// The configured request signer, if any.
+	requestSigner func(*http.Request) error
+
+	captureFailedRequests bool
+
+	// The configured retry hook, if any.
+	onRetry func(attempt int, resp *http.Response, err error) bool
+
+	confirmDestructive bool
+
+	// The deprecated endpoints a warning was logged for.
+	deprecationMu       sync.Mutex
+	deprecationWarnings map[string]bool
+
+	// Closed when the client is closed.
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	AgentPools                 AgentPools
+	Applies                    Applies
+	ConfigurationVersions      ConfigurationVersions
+	NotificationConfigurations NotificationConfigurations
+	OAuthClients               OAuthClients
+	OAuthTokens                OAuthTokens
+	Organizations              Organizations
+	OrganizationTokens         OrganizationTokens
+	Plans                      Plans
+	Projects                   Projects
+	Policies                   Policies
+	PolicyChecks               PolicyChecks
+	PolicySets                 PolicySets
+	Runs                       Runs
+	SSHKeys                    SSHKeys
+	StateVersions              StateVersions
+	Teams                      Teams
+	TeamAccess                 TeamAccesses
+	TeamProjectAccess          TeamProjectAccesses
+	TeamMembers                TeamMembers
+	TeamTokens                 TeamTokens
+	Users                      Users
+	Variables                  Variables
+	VariableSets               VariableSets
+	Workspaces                 Workspaces
 }
 
 // NewClient creates a new Terraform Enterprise API client.
@@ -142,6 +300,27 @@ func NewClient(cfg *Config) (*Client, error) {
 		if cfg.HTTPClient != nil {
 			config.HTTPClient = cfg.HTTPClient
 		}
+		if cfg.TolerateParseErrors {
+			config.TolerateParseErrors = true
+		}
+		if cfg.MaxResponseBytes > 0 {
+			config.MaxResponseBytes = cfg.MaxResponseBytes
+		}
+		if cfg.Logger != nil {
+			config.Logger = cfg.Logger
+		}
+		if cfg.RequestSigner != nil {
+			config.RequestSigner = cfg.RequestSigner
+		}
+		if cfg.CaptureFailedRequests {
+			config.CaptureFailedRequests = true
+		}
+		if cfg.OnRetry != nil {
+			config.OnRetry = cfg.OnRetry
+		}
+		if cfg.ConfirmDestructive {
+			config.ConfirmDestructive = true
+		}
 	}
 
 	// Parse the address to make sure its a valid URL.
@@ -162,9 +341,17 @@ func NewClient(cfg *Config) (*Client, error) {
 
 	// Create the client.
 	client := &Client{
-		baseURL: baseURL,
-		token:   config.Token,
-		headers: config.Headers,
+		baseURL:               baseURL,
+		token:                 config.Token,
+		headers:               config.Headers,
+		tolerateParseErrors:   config.TolerateParseErrors,
+		maxResponseBytes:      config.MaxResponseBytes,
+		logger:                config.Logger,
+		requestSigner:         config.RequestSigner,
+		captureFailedRequests: config.CaptureFailedRequests,
+		onRetry:               config.OnRetry,
+		confirmDestructive:    config.ConfirmDestructive,
+		closed:                make(chan struct{}),
 		http: &retryablehttp.Client{
 			Backoff:      rateLimitBackoff,
 			CheckRetry:   rateLimitRetry,
@@ -176,19 +363,27 @@ func NewClient(cfg *Config) (*Client, error) {
 		},
 	}
 
+	// Consult the retry hook, if any, before retrying.
+	if client.onRetry != nil {
+		client.http.CheckRetry = client.checkRetry
+	}
+
 	// Configure the rate limiter.
 	if err := client.configureLimiter(); err != nil {
 		return nil, err
 	}
 
 	// Create the services.
+	client.AgentPools = &agentPools{client: client}
 	client.Applies = &applies{client: client}
 	client.ConfigurationVersions = &configurationVersions{client: client}
+	client.NotificationConfigurations = &notificationConfigurations{client: client}
 	client.OAuthClients = &oAuthClients{client: client}
 	client.OAuthTokens = &oAuthTokens{client: client}
 	client.Organizations = &organizations{client: client}
 	client.OrganizationTokens = &organizationTokens{client: client}
 	client.Plans = &plans{client: client}
+	client.Projects = &projects{client: client}
 	client.Policies = &policies{client: client}
 	client.PolicyChecks = &policyChecks{client: client}
 	client.PolicySets = &policySets{client: client}
@@ -197,10 +392,12 @@ func NewClient(cfg *Config) (*Client, error) {
 	client.StateVersions = &stateVersions{client: client}
 	client.Teams = &teams{client: client}
 	client.TeamAccess = &teamAccesses{client: client}
+	client.TeamProjectAccess = &teamProjectAccesses{client: client}
 	client.TeamMembers = &teamMembers{client: client}
 	client.TeamTokens = &teamTokens{client: client}
 	client.Users = &users{client: client}
 	client.Variables = &variables{client: client}
+	client.VariableSets = &variableSets{client: client}
 	client.Workspaces = &workspaces{client: client}
 
 	return client, nil
@@ -224,6 +421,61 @@ func rateLimitRetry(ctx context.Context, resp *http.Response, err error) (bool,
 	return false, nil
 }
 
+// downloadKey is the context key used to mark requests for downloads,
+// whose responses are not limited by Config.MaxResponseBytes.
+type downloadKey struct{}
+
+// withDownload marks the requests made with the returned context as
+// downloads.
+func withDownload(ctx context.Context) context.Context {
+	return context.WithValue(ctx, downloadKey{}, true)
+}
+
+// retryAttemptKey is the context key used to count the retries of a request.
+type retryAttemptKey struct{}
+
+// withRequestContext returns a copy of req with the context ctx, set up
+// like every request sent by the client: the retries of the request are
+// counted for the retry hook, and the correlation ID of ctx, if any, is
+// added as a header.
+func (c *Client) withRequestContext(ctx context.Context, req *retryablehttp.Request) *retryablehttp.Request {
+	if c.onRetry != nil {
+		ctx = context.WithValue(ctx, retryAttemptKey{}, new(int))
+	}
+
+	req = req.WithContext(ctx)
+
+	if correlationID, ok := CorrelationIDFromContext(ctx); ok {
+		req.Header.Set(CorrelationIDHeader, correlationID)
+	}
+
+	return req
+}
+
+// checkRetry provides a callback for Client.CheckRetry when a retry hook is
+// configured. It consults the hook before each retry allowed by
+// rateLimitRetry.
+func (c *Client) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, checkErr := rateLimitRetry(ctx, resp, err)
+	if !retry {
+		return retry, checkErr
+	}
+
+	attempt := 1
+	if n, ok := ctx.Value(retryAttemptKey{}).(*int); ok {
+		*n++
+		attempt = *n
+	}
+
+	// The retry policy is also checked after the last attempt, so
+	// don't consult the hook for retries that will not be made.
+	if attempt > c.http.RetryMax {
+		return retry, checkErr
+	}
+
+	return c.onRetry(attempt, resp, err), checkErr
+}
+
 // rateLimitBackoff provides a callback for Client.Backoff which will use the
 // X-RateLimit_Reset header to determine the time to wait. We add some jitter
 // to prevent a thundering herd.
@@ -263,6 +515,11 @@ func (c *Client) configureLimiter() error {
 	}
 	req.Header.Set("Accept", "application/vnd.api+json")
 
+	// Sign the request, if needed.
+	if err := c.signRequest(req); err != nil {
+		return err
+	}
+
 	// Make a single request to retrieve the rate limit headers.
 	resp, err := c.http.HTTPClient.Do(req)
 	if err != nil {
@@ -292,6 +549,186 @@ func (c *Client) configureLimiter() error {
 	return nil
 }
 
+// wait blocks until the rate limiter hands out a new token, taking the
+// request priority stored in ctx into account. Normal priority requests
+// will not claim a token as long as high priority requests are waiting.
+func (c *Client) wait(ctx context.Context) error {
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+
+	if priorityFromContext(ctx) == PriorityHigh {
+		atomic.AddInt32(&c.prioritized, 1)
+		defer atomic.AddInt32(&c.prioritized, -1)
+		return c.limiterWait(ctx)
+	}
+
+	// Hold back while there are high priority requests waiting.
+	for atomic.LoadInt32(&c.prioritized) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.closed:
+			return ErrClientClosed
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	return c.limiterWait(ctx)
+}
+
+// limiterWait waits for the rate limiter, and stops waiting with
+// ErrClientClosed when the client is closed.
+func (c *Client) limiterWait(ctx context.Context) error {
+	ctx, cancel := c.closeContext(ctx)
+	defer cancel()
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		if c.isClosed() {
+			return ErrClientClosed
+		}
+		return err
+	}
+	return nil
+}
+
+// closeContext returns a copy of ctx that is also canceled when the client
+// is closed. The returned cancel function must be called to release the
+// resources of the context.
+func (c *Client) closeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-c.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
+// Close closes the client and the idle connections of the underlying
+// transport. After the client is closed, any further requests return
+// ErrClientClosed. Requests that are in flight or waiting for the rate
+// limiter are interrupted and return ErrClientClosed as well, and so do
+// the background goroutines of the streaming helpers, such as
+// Variables.StreamOrganization and the log readers. Calling Close more
+// than once has no effect.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.http.HTTPClient.CloseIdleConnections()
+	})
+	return nil
+}
+
+// isClosed reports whether the client is closed.
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ServerTime returns the current time of the server, as reported by the
+// Date header of a request to the API. The Date header has a resolution of
+// one second.
+func (c *Client) ServerTime(ctx context.Context) (time.Time, error) {
+	serverTime, _, err := c.serverTime(ctx)
+	return serverTime, err
+}
+
+// ClockSkew returns the difference between the time of the server and the
+// local time, which is positive when the clock of the server is ahead. The
+// local time is taken halfway through the request to compensate for the
+// latency. As the Date header has a resolution of one second, so does the
+// returned skew.
+func (c *Client) ClockSkew(ctx context.Context) (time.Duration, error) {
+	serverTime, localTime, err := c.serverTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return serverTime.Sub(localTime).Round(time.Second), nil
+}
+
+// serverTime requests the API root and returns the time of the server
+// together with the local time halfway through the request.
+func (c *Client) serverTime(ctx context.Context) (time.Time, time.Time, error) {
+	if err := c.wait(ctx); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	req, err := http.NewRequest("GET", c.baseURL.String(), nil)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	req = req.WithContext(ctx)
+
+	// Attach the default headers.
+	for k, v := range c.headers {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/vnd.api+json")
+
+	// Sign the request, if needed.
+	if err := c.signRequest(req); err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	start := time.Now()
+	resp, err := c.http.HTTPClient.Do(req)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	resp.Body.Close()
+	localTime := start.Add(time.Since(start) / 2)
+
+	date := resp.Header.Get("Date")
+	if date == "" {
+		return time.Time{}, time.Time{}, errors.New("server did not return a Date header")
+	}
+
+	// ParseTime accepts all three date formats allowed by HTTP/1.1.
+	serverTime, err := http.ParseTime(strings.TrimSpace(date))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid Date header %q: %v", date, err)
+	}
+
+	return serverTime, localTime, nil
+}
+
+// NewRequest creates an API request in the same way the service methods do,
+// but returns it as a plain *http.Request without sending it. This can be
+// used to inspect the generated requests, or to send them in a custom way.
+// The path and v are handled as described for newRequest.
+func (c *Client) NewRequest(method, path string, v interface{}) (*http.Request, error) {
+	u, body, headers, err := c.encodeRequest(method, path, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	case io.Reader:
+		reader = b
+	default:
+		return nil, fmt.Errorf("unsupported request body type: %T", body)
+	}
+
+	req, err := http.NewRequest(method, u.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers
+
+	return req, nil
+}
+
 // newRequest creates an API request. A relative URL path can be provided in
 // path, in which case it is resolved relative to the apiVersionPath of the
 // Client. Relative URL paths should always be specified without a preceding
@@ -300,11 +737,67 @@ func (c *Client) configureLimiter() error {
 // request body. If the method is GET, the value will be parsed and added as
 // query parameters.
 func (c *Client) newRequest(method, path string, v interface{}) (*retryablehttp.Request, error) {
-	u, err := c.baseURL.Parse(path)
+	u, body, headers, err := c.encodeRequest(method, path, v)
 	if err != nil {
 		return nil, err
 	}
 
+	// Buffer streaming bodies when signing requests, so the body can be
+	// read both by the request signer and when sending the request.
+	if r, ok := body.(io.Reader); ok && c.requestSigner != nil {
+		if _, ok := r.(*bytes.Buffer); !ok {
+			buf := bytes.NewBuffer(nil)
+			if _, err := buf.ReadFrom(r); err != nil {
+				return nil, err
+			}
+			body = buf
+		}
+	}
+
+	req, err := retryablehttp.NewRequest(method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if buf, ok := body.(*bytes.Buffer); ok {
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(buf.Bytes())), nil
+		}
+	}
+
+	for k, v := range headers {
+		req.Header[k] = v
+	}
+
+	return req, nil
+}
+
+// signRequest calls the configured request signer, if any. A request body
+// is made available to the signer through req.Body.
+func (c *Client) signRequest(req *http.Request) error {
+	if c.requestSigner == nil {
+		return nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		req.Body = body
+	}
+
+	return c.requestSigner(req)
+}
+
+// encodeRequest resolves the URL, encodes the body and collects the headers
+// for an API request, as described for newRequest.
+func (c *Client) encodeRequest(method, path string, v interface{}) (*url.URL, interface{}, http.Header, error) {
+	u, err := c.baseURL.Parse(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	// Create a request specific headers map.
 	reqHeaders := make(http.Header)
 	reqHeaders.Set("Authorization", "Bearer "+c.token)
@@ -317,7 +810,7 @@ func (c *Client) newRequest(method, path string, v interface{}) (*retryablehttp.
 		if v != nil {
 			q, err := query.Values(v)
 			if err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 			u.RawQuery = q.Encode()
 		}
@@ -328,7 +821,7 @@ func (c *Client) newRequest(method, path string, v interface{}) (*retryablehttp.
 		if v != nil {
 			buf := bytes.NewBuffer(nil)
 			if err := jsonapi.MarshalPayloadWithoutIncluded(buf, v); err != nil {
-				return nil, err
+				return nil, nil, nil, err
 			}
 			body = buf
 		}
@@ -338,22 +831,19 @@ func (c *Client) newRequest(method, path string, v interface{}) (*retryablehttp.
 		body = v
 	}
 
-	req, err := retryablehttp.NewRequest(method, u.String(), body)
-	if err != nil {
-		return nil, err
-	}
+	headers := make(http.Header)
 
 	// Set the default headers.
 	for k, v := range c.headers {
-		req.Header[k] = v
+		headers[k] = v
 	}
 
 	// Set the request specific headers.
 	for k, v := range reqHeaders {
-		req.Header[k] = v
+		headers[k] = v
 	}
 
-	return req, nil
+	return u, body, headers, nil
 }
 
 // do sends an API request and returns the API response. The API response
@@ -366,14 +856,26 @@ func (c *Client) newRequest(method, path string, v interface{}) (*retryablehttp.
 // The provided ctx must be non-nil. If it is canceled or times out, ctx.Err()
 // will be returned.
 func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface{}) error {
+	// Interrupt the request when the client is closed.
+	ctx, cancel := c.closeContext(ctx)
+	defer cancel()
+
 	// Wait will block until the limiter can obtain a new token
 	// or returns an error if the given context is canceled.
-	if err := c.limiter.Wait(ctx); err != nil {
+	if err := c.wait(ctx); err != nil {
 		return err
 	}
 
 	// Add the context to the request.
-	req = req.WithContext(ctx)
+	req = c.withRequestContext(ctx, req)
+	ctx = req.Context()
+
+	correlationID, _ := CorrelationIDFromContext(ctx)
+
+	// Sign the request, if needed.
+	if err := c.signRequest(req.Request); err != nil {
+		return withCorrelationID(correlationID, err)
+	}
 
 	// Execute the request and check the response.
 	resp, err := c.http.Do(req)
@@ -382,16 +884,29 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 		// the context's error is probably more useful.
 		select {
 		case <-ctx.Done():
+			if c.isClosed() {
+				return ErrClientClosed
+			}
 			return ctx.Err()
 		default:
-			return err
+			return withCorrelationID(correlationID, c.withCapturedRequest(req.Request, err))
 		}
 	}
 	defer resp.Body.Close()
 
+	// Warn about deprecated endpoints.
+	c.checkDeprecation(req.Request, resp)
+
+	// Limit the size of the response body, but keep the raw
+	// body around for downloads.
+	rawBody := resp.Body
+	if c.maxResponseBytes > 0 {
+		resp.Body = ioutil.NopCloser(io.LimitReader(rawBody, c.maxResponseBytes+1))
+	}
+
 	// Basic response checking.
 	if err := checkResponseCode(resp); err != nil {
-		return err
+		return withCorrelationID(correlationID, c.withCapturedRequest(req.Request, err))
 	}
 
 	// Return here if decoding the response isn't needed.
@@ -399,10 +914,33 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 		return nil
 	}
 
-	// If v implements io.Writer, write the raw response body.
+	// If v implements io.Writer, write the response body. Downloads
+	// are written as-is, without limiting their size.
 	if w, ok := v.(io.Writer); ok {
-		_, err = io.Copy(w, resp.Body)
-		return err
+		if download, _ := ctx.Value(downloadKey{}).(bool); download {
+			_, err = io.Copy(w, rawBody)
+			return err
+		}
+		n, err := io.Copy(w, resp.Body)
+		if err != nil {
+			return err
+		}
+		if c.maxResponseBytes > 0 && n > c.maxResponseBytes {
+			return ErrResponseTooLarge
+		}
+		return nil
+	}
+
+	// Make sure the response body does not exceed the limit.
+	if c.maxResponseBytes > 0 {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		if int64(len(body)) > c.maxResponseBytes {
+			return ErrResponseTooLarge
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
 	}
 
 	// Get the value of v so we can test if it's a struct.
@@ -433,9 +971,23 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 	reader := io.TeeReader(resp.Body, body)
 
 	// Unmarshal as a list of values as v.Items is a slice.
+	var parseErr *ParseError
 	raw, err := jsonapi.UnmarshalManyPayload(reader, items.Type().Elem())
 	if err != nil {
-		return err
+		if !c.tolerateParseErrors {
+			return err
+		}
+
+		// Make sure the buffer contains the complete response body.
+		if _, err := io.Copy(ioutil.Discard, reader); err != nil {
+			return err
+		}
+
+		// Retry by unmarshaling the items one by one.
+		raw, parseErr, err = unmarshalManyTolerant(body.Bytes(), items.Type().Elem())
+		if err != nil {
+			return err
+		}
 	}
 
 	// Make a new slice to hold the results.
@@ -460,9 +1012,218 @@ func (c *Client) do(ctx context.Context, req *retryablehttp.Request, v interface
 	// Pointer-swap the decoded pagination details.
 	pagination.Set(reflect.ValueOf(p))
 
+	// Return any skipped items together with the parsed items.
+	if parseErr != nil {
+		return parseErr
+	}
+
 	return nil
 }
 
+// maxDeprecationWarnings is the maximum number of deprecated endpoints a
+// warning is logged for.
+const maxDeprecationWarnings = 100
+
+// checkDeprecation logs a warning, once per endpoint, when the response
+// has a Deprecation or Sunset header. Nothing is logged when no logger is
+// configured, or when warnings were already logged for
+// maxDeprecationWarnings endpoints.
+func (c *Client) checkDeprecation(req *http.Request, resp *http.Response) {
+	if c.logger == nil {
+		return
+	}
+
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	endpoint := req.Method + " " + c.baseURL.Path + endpointTemplate(
+		strings.TrimPrefix(req.URL.Path, c.baseURL.Path))
+
+	c.deprecationMu.Lock()
+	if c.deprecationWarnings[endpoint] || len(c.deprecationWarnings) >= maxDeprecationWarnings {
+		c.deprecationMu.Unlock()
+		return
+	}
+	if c.deprecationWarnings == nil {
+		c.deprecationWarnings = make(map[string]bool)
+	}
+	c.deprecationWarnings[endpoint] = true
+	c.deprecationMu.Unlock()
+
+	msg := fmt.Sprintf("[WARN] go-tfe: %s is deprecated", endpoint)
+	if t, err := http.ParseTime(deprecation); err == nil {
+		msg += fmt.Sprintf(" since %s", t.Format("2006-01-02"))
+	}
+	if sunset != "" {
+		if t, err := http.ParseTime(sunset); err == nil {
+			sunset = t.Format("2006-01-02")
+		}
+		msg += fmt.Sprintf(" and will be removed on %s", sunset)
+	}
+	if id := req.Header.Get(CorrelationIDHeader); id != "" {
+		msg += fmt.Sprintf(" (correlation ID: %s)", id)
+	}
+
+	c.logger.Printf("%s", msg)
+}
+
+// endpointTemplate replaces the IDs and names in an API path, relative to
+// the base path, with a placeholder, so that requests for different
+// resources map to the same endpoint. Every segment following a collection
+// is considered to be an ID, except for the static segments following
+// "actions" and "relationships".
+func endpointTemplate(path string) string {
+	segments := strings.Split(path, "/")
+
+	isID := false
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if isID {
+			segments[i] = ":id"
+			isID = false
+			continue
+		}
+		isID = segment != "admin" && segment != "actions" && segment != "relationships"
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// ConflictError is returned when receiving a 409, which indicates that the
+// request conflicts with the current state of a resource, for example when
+// creating a variable with a key that is already in use.
+type ConflictError struct {
+	// The error details returned by the server.
+	Detail string
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	return e.Detail
+}
+
+// CorrelationError wraps the errors returned for requests that were made
+// with a correlation ID, see WithCorrelationID. Use errors.Is or errors.As
+// to inspect the wrapped error.
+type CorrelationError struct {
+	// The correlation ID sent with the request.
+	CorrelationID string
+
+	// The error returned for the request.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *CorrelationError) Error() string {
+	return fmt.Sprintf("%v (correlation ID: %s)", e.Err, e.CorrelationID)
+}
+
+// Unwrap returns the wrapped error.
+func (e *CorrelationError) Unwrap() error {
+	return e.Err
+}
+
+// withCorrelationID wraps err in a *CorrelationError when a correlation
+// ID is set, and returns err unchanged otherwise.
+func withCorrelationID(correlationID string, err error) error {
+	if correlationID == "" {
+		return err
+	}
+	return &CorrelationError{CorrelationID: correlationID, Err: err}
+}
+
+// ParseError is returned by List methods, together with the successfully
+// parsed items, when TolerateParseErrors is enabled and one or more items
+// of the list could not be parsed.
+type ParseError struct {
+	Skipped []*SkippedItem
+}
+
+// SkippedItem describes a list item that could not be parsed.
+type SkippedItem struct {
+	// The position of the item in the returned page.
+	Index int
+
+	// The JSON:API type and ID of the item, if available.
+	Type string
+	ID   string
+
+	// The error encountered while parsing the item.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	var errs []string
+	for _, item := range e.Skipped {
+		errs = append(errs, fmt.Sprintf("item %d (%s %s): %v", item.Index, item.Type, item.ID, item.Err))
+	}
+	return fmt.Sprintf("skipped %d item(s) that could not be parsed:\n\n%s", len(errs), strings.Join(errs, "\n"))
+}
+
+// isParseError returns true if err is a *ParseError.
+func isParseError(err error) bool {
+	_, ok := err.(*ParseError)
+	return ok
+}
+
+// unmarshalManyTolerant unmarshals each item of a list payload separately,
+// so a single malformed item does not prevent the other items from being
+// returned. Items that cannot be unmarshaled are described by the returned
+// *ParseError, which is nil if all items were unmarshaled successfully.
+func unmarshalManyTolerant(data []byte, t reflect.Type) ([]interface{}, *ParseError, error) {
+	var payload struct {
+		Data     []json.RawMessage `json:"data"`
+		Included json.RawMessage   `json:"included,omitempty"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, nil, err
+	}
+
+	var models []interface{}
+	var skipped []*SkippedItem
+
+	for i, item := range payload.Data {
+		one, err := json.Marshal(struct {
+			Data     json.RawMessage `json:"data"`
+			Included json.RawMessage `json:"included,omitempty"`
+		}{item, payload.Included})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		model := reflect.New(t.Elem())
+		if err := jsonapi.UnmarshalPayload(bytes.NewReader(one), model.Interface()); err != nil {
+			var node struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			}
+			json.Unmarshal(item, &node)
+
+			skipped = append(skipped, &SkippedItem{
+				Index: i,
+				Type:  node.Type,
+				ID:    node.ID,
+				Err:   err,
+			})
+			continue
+		}
+
+		models = append(models, model.Interface())
+	}
+
+	if len(skipped) > 0 {
+		return models, &ParseError{Skipped: skipped}, nil
+	}
+
+	return models, nil, nil
+}
+
 // ListOptions is used to specify pagination options when making API requests.
 // Pagination allows breaking up large result sets into chunks, or "pages".
 type ListOptions struct {
@@ -519,7 +1280,16 @@ func checkResponseCode(r *http.Response) error {
 		}
 	}
 
-	// Decode the error payload.
+	err := decodeErrorPayload(r)
+	if r.StatusCode == 409 {
+		return &ConflictError{Detail: err.Error()}
+	}
+
+	return err
+}
+
+// decodeErrorPayload decodes the error payload of the response into an error.
+func decodeErrorPayload(r *http.Response) error {
 	errPayload := &jsonapi.ErrorsPayload{}
 	err := json.NewDecoder(r.Body).Decode(errPayload)
 	if err != nil || len(errPayload.Errors) == 0 {
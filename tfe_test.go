@@ -2,10 +2,20 @@ package tfe
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"golang.org/x/time/rate"
 )
@@ -263,6 +273,405 @@ func TestClient_configureLimiter(t *testing.T) {
 	}
 }
 
+func TestClient_withPriority(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.WriteHeader(404) // We query the configured base URL which should return a 404.
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("defaults to normal priority", func(t *testing.T) {
+		if p := priorityFromContext(context.Background()); p != PriorityNormal {
+			t.Fatalf("expected normal priority, got: %d", p)
+		}
+	})
+
+	t.Run("stores the priority in the context", func(t *testing.T) {
+		ctx := WithPriority(context.Background(), PriorityHigh)
+		if p := priorityFromContext(ctx); p != PriorityHigh {
+			t.Fatalf("expected high priority, got: %d", p)
+		}
+	})
+
+	t.Run("normal priority waits for high priority requests", func(t *testing.T) {
+		atomic.AddInt32(&client.prioritized, 1)
+		defer atomic.AddInt32(&client.prioritized, -1)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if err := client.wait(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("expected deadline exceeded error, got: %v", err)
+		}
+
+		ctx = WithPriority(context.Background(), PriorityHigh)
+		if err := client.wait(ctx); err != nil {
+			t.Fatalf("expected high priority request to skip the line, got: %v", err)
+		}
+	})
+}
+
+func TestClient_tolerateParseErrors(t *testing.T) {
+	testedCalls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testedCalls++
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if testedCalls == 1 {
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.WriteHeader(404) // We query the configured base URL which should return a 404.
+			return
+		}
+
+		w.Write([]byte(`{
+  "data": [
+    {"id": "ws-1", "type": "workspaces", "attributes": {"name": "one"}},
+    {"id": "ws-2", "type": "workspaces", "attributes": {"name": ["two"]}},
+    {"id": "ws-3", "type": "workspaces", "attributes": {"name": "three"}}
+  ],
+  "meta": {"pagination": {"current-page": 1, "total-count": 3}}
+}`))
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	}
+
+	t.Run("fails by default", func(t *testing.T) {
+		testedCalls = 0
+
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wl, err := client.Workspaces.List(context.Background(), "organization", WorkspaceListOptions{})
+		if err == nil {
+			t.Fatal("expected an error, got nil")
+		}
+		if isParseError(err) {
+			t.Fatalf("expected a strict parse error, got: %v", err)
+		}
+		if wl != nil {
+			t.Fatalf("expected no workspaces, got: %v", wl)
+		}
+	})
+
+	t.Run("returns the parsed items when tolerated", func(t *testing.T) {
+		testedCalls = 0
+		cfg.TolerateParseErrors = true
+
+		client, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wl, err := client.Workspaces.List(context.Background(), "organization", WorkspaceListOptions{})
+		parseErr, ok := err.(*ParseError)
+		if !ok {
+			t.Fatalf("expected a *ParseError, got: %v", err)
+		}
+		if len(parseErr.Skipped) != 1 {
+			t.Fatalf("expected 1 skipped item, got: %d", len(parseErr.Skipped))
+		}
+		if skipped := parseErr.Skipped[0]; skipped.Index != 1 || skipped.ID != "ws-2" || skipped.Type != "workspaces" {
+			t.Fatalf("unexpected skipped item: %+v", skipped)
+		}
+
+		if len(wl.Items) != 2 {
+			t.Fatalf("expected 2 workspaces, got: %d", len(wl.Items))
+		}
+		if wl.Items[0].Name != "one" || wl.Items[1].Name != "three" {
+			t.Fatalf("unexpected workspaces: %q, %q", wl.Items[0].Name, wl.Items[1].Name)
+		}
+		if wl.TotalCount != 3 {
+			t.Fatalf("expected a total count of 3, got: %d", wl.TotalCount)
+		}
+	})
+}
+
+func TestClient_close(t *testing.T) {
+	testedCalls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testedCalls++
+
+		if testedCalls == 1 {
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.WriteHeader(404) // We query the configured base URL which should return a 404.
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Write([]byte(`{"data": {"id": "org-1", "type": "organizations"}}`))
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Organizations.Read(context.Background(), "org-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error closing the client: %v", err)
+	}
+
+	// Closing the client again should be a no-op.
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error closing the client twice: %v", err)
+	}
+
+	if _, err := client.Organizations.Read(context.Background(), "org-1"); err != ErrClientClosed {
+		t.Fatalf("expected %v, got: %v", ErrClientClosed, err)
+	}
+
+	if testedCalls != 2 {
+		t.Fatalf("expected 2 calls, got: %d", testedCalls)
+	}
+}
+
+func TestClient_closeInterrupts(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+
+		if r.URL.Path == "/api/v2/organizations/org-1" {
+			received <- struct{}{}
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v2/organizations/org-1/workspaces":
+			w.Write([]byte(`{"data": [{"type": "workspaces", "id": "ws-1", "attributes": {"name": "one"}}]}`))
+		case "/api/v2/vars":
+			// Always advertise a next page, so the walk only
+			// ends when the client is closed.
+			w.Write([]byte(`{
+				"data": [{"type": "vars", "id": "var-1", "attributes": {"key": "foo"}}],
+				"meta": {"pagination": {"current-page": 1, "next-page": 2}}
+			}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	newClient := func() *Client {
+		client, err := NewClient(&Config{
+			Address:    ts.URL,
+			Token:      "dummy-token",
+			HTTPClient: ts.Client(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	t.Run("with a request in flight", func(t *testing.T) {
+		client := newClient()
+
+		errc := make(chan error, 1)
+		go func() {
+			_, err := client.Organizations.Read(context.Background(), "org-1")
+			errc <- err
+		}()
+
+		<-received
+		client.Close()
+
+		select {
+		case err := <-errc:
+			if err != ErrClientClosed {
+				t.Fatalf("expected %v, got: %v", ErrClientClosed, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the request to be interrupted")
+		}
+	})
+
+	t.Run("with a stream that is not consumed", func(t *testing.T) {
+		client := newClient()
+
+		vc, errc := client.Variables.StreamOrganization(context.Background(), "org-1")
+
+		// Wait until the first variable is sent, so that the stream is
+		// blocked sending the next one when the client is closed.
+		<-vc
+		client.Close()
+
+		done := make(chan error, 1)
+		go func() {
+			for range vc {
+			}
+			done <- <-errc
+		}()
+
+		select {
+		case err := <-done:
+			if err != ErrClientClosed {
+				t.Fatalf("expected %v, got: %v", ErrClientClosed, err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the stream to be stopped")
+		}
+	})
+}
+
+func TestClient_conflictError(t *testing.T) {
+	payload, err := ioutil.ReadFile("test-fixtures/errors/conflict.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testedCalls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testedCalls++
+
+		if testedCalls == 1 {
+			w.Header().Set("X-RateLimit-Limit", "30")
+			w.WriteHeader(404) // We query the configured base URL which should return a 404.
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(409)
+		w.Write(payload)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Variables.Create(context.Background(), VariableCreateOptions{
+		Key:       String("foo"),
+		Value:     String("bar"),
+		Category:  Category(CategoryTerraform),
+		Workspace: &Workspace{ID: "ws-1"},
+	})
+
+	var cerr *ConflictError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected a *ConflictError, got: %v", err)
+	}
+
+	expected := "conflict\n\nKey has already been taken"
+	if cerr.Detail != expected {
+		t.Fatalf("expected detail %q, got: %q", expected, cerr.Detail)
+	}
+}
+
+func TestClient_NewRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.WriteHeader(204) // We query the configured base URL which should return a 204.
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		Headers:    make(http.Header),
+		HTTPClient: ts.Client(),
+	}
+	cfg.Headers.Set("X-Custom", "custom")
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("with query options", func(t *testing.T) {
+		req, err := client.NewRequest("GET", "organizations/foo/workspaces", &WorkspaceListOptions{
+			Search: String("bar"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := ts.URL + "/api/v2/organizations/foo/workspaces?search%5Bname%5D=bar"
+		if req.URL.String() != expected {
+			t.Fatalf("expected URL %q, got: %q", expected, req.URL.String())
+		}
+		if req.Body != nil {
+			t.Fatal("expected no body")
+		}
+		if v := req.Header.Get("Authorization"); v != "Bearer dummy-token" {
+			t.Fatalf("unexpected Authorization header: %q", v)
+		}
+		if v := req.Header.Get("X-Custom"); v != "custom" {
+			t.Fatalf("unexpected X-Custom header: %q", v)
+		}
+	})
+
+	t.Run("with a body", func(t *testing.T) {
+		req, err := client.NewRequest("POST", "organizations/foo/workspaces", &WorkspaceCreateOptions{
+			Name: String("bar"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v := req.Header.Get("Content-Type"); v != "application/vnd.api+json" {
+			t.Fatalf("unexpected Content-Type header: %q", v)
+		}
+
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if req.ContentLength != int64(len(body)) {
+			t.Fatalf("expected content length %d, got: %d", len(body), req.ContentLength)
+		}
+		if !strings.Contains(string(body), `"name":"bar"`) {
+			t.Fatalf("unexpected body: %s", body)
+		}
+	})
+}
+
 func setupEnvVars(token, address string) func() {
 	origToken := os.Getenv("TFE_TOKEN")
 	origAddress := os.Getenv("TFE_ADDRESS")
@@ -275,3 +684,704 @@ func setupEnvVars(token, address string) func() {
 		os.Setenv("TFE_ADDRESS", origAddress)
 	}
 }
+
+func TestClient_correlationID(t *testing.T) {
+	var received []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		received = append(received, r.Header.Get(CorrelationIDHeader))
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	cfg := &Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("without a correlation ID", func(t *testing.T) {
+		received = nil
+
+		_, err := client.Organizations.Read(context.Background(), "foo")
+		if err != ErrResourceNotFound {
+			t.Fatalf("expected %v, got: %v", ErrResourceNotFound, err)
+		}
+		if len(received) != 1 || received[0] != "" {
+			t.Fatalf("expected no correlation ID header, got: %q", received)
+		}
+	})
+
+	t.Run("with a correlation ID", func(t *testing.T) {
+		received = nil
+
+		ctx := WithCorrelationID(context.Background(), "op-123")
+		_, err := client.Organizations.Read(ctx, "foo")
+		if len(received) != 1 || received[0] != "op-123" {
+			t.Fatalf("expected correlation ID header %q, got: %q", "op-123", received)
+		}
+
+		var cerr *CorrelationError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("expected a *CorrelationError, got: %v", err)
+		}
+		if cerr.CorrelationID != "op-123" {
+			t.Fatalf("expected correlation ID %q, got: %q", "op-123", cerr.CorrelationID)
+		}
+		if !errors.Is(err, ErrResourceNotFound) {
+			t.Fatalf("expected the error to wrap %v, got: %v", ErrResourceNotFound, err)
+		}
+		if !strings.Contains(err.Error(), "op-123") {
+			t.Fatalf("expected the error to contain the correlation ID, got: %v", err)
+		}
+	})
+}
+
+func TestClient_maxResponseBytes(t *testing.T) {
+	body := `{"data": [{"type": "organizations", "id": "` + strings.Repeat("a", 200) + `"}]}`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		w.WriteHeader(200)
+		w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	newClient := func(max int64) *Client {
+		client, err := NewClient(&Config{
+			Address:          ts.URL,
+			Token:            "dummy-token",
+			HTTPClient:       ts.Client(),
+			MaxResponseBytes: max,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return client
+	}
+
+	t.Run("when the response is too large", func(t *testing.T) {
+		client := newClient(100)
+
+		_, err := client.Organizations.List(context.Background(), OrganizationListOptions{})
+		if err != ErrResponseTooLarge {
+			t.Fatalf("expected %v, got: %v", ErrResponseTooLarge, err)
+		}
+	})
+
+	t.Run("when the response is within the limit", func(t *testing.T) {
+		client := newClient(int64(len(body)))
+
+		orgl, err := client.Organizations.List(context.Background(), OrganizationListOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(orgl.Items) != 1 {
+			t.Fatalf("expected 1 organization, got: %d", len(orgl.Items))
+		}
+	})
+
+	t.Run("without a limit", func(t *testing.T) {
+		client := newClient(0)
+
+		_, err := client.Organizations.List(context.Background(), OrganizationListOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("does not limit downloads", func(t *testing.T) {
+		client := newClient(100)
+
+		state, err := client.StateVersions.Download(context.Background(), "state")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(state) != body {
+			t.Fatalf("expected the complete body, got %d bytes", len(state))
+		}
+	})
+
+	t.Run("when an output value is too large", func(t *testing.T) {
+		client := newClient(100)
+
+		_, err := client.Workspaces.OutputValue(context.Background(), "ws-123", "name")
+		if err != ErrResponseTooLarge {
+			t.Fatalf("expected %v, got: %v", ErrResponseTooLarge, err)
+		}
+	})
+}
+
+type testLogger struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, fmt.Sprintf(format, v...))
+}
+
+func TestClient_deprecationWarnings(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		switch {
+		case r.URL.Path == "/api/v2/":
+			w.WriteHeader(204)
+			return
+		case strings.HasPrefix(r.URL.Path, "/api/v2/organizations/deprecated"):
+			w.Header().Set("Deprecation", "Sun, 01 Mar 2020 00:00:00 GMT")
+			w.Header().Set("Sunset", "Tue, 01 Sep 2020 00:00:00 GMT")
+		case r.URL.Path == "/api/v2/runs/run-123/actions/apply":
+			w.Header().Set("Sunset", "Tue, 01 Sep 2020 00:00:00 GMT")
+		}
+		w.WriteHeader(202)
+	}))
+	defer ts.Close()
+
+	logger := &testLogger{}
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		Logger:     logger,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for i, path := range []string{
+		"organizations/deprecated-1",
+		"organizations/deprecated-2",
+		"runs/run-123/actions/apply",
+		"organizations/current",
+	} {
+		req, err := client.newRequest("POST", path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(WithCorrelationID(ctx, fmt.Sprintf("req-%d", i)), req, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	expected := []string{
+		"[WARN] go-tfe: POST /api/v2/organizations/:id is deprecated since 2020-03-01 and will be removed on 2020-09-01 (correlation ID: req-0)",
+		"[WARN] go-tfe: POST /api/v2/runs/:id/actions/apply is deprecated and will be removed on 2020-09-01 (correlation ID: req-2)",
+	}
+	if !reflect.DeepEqual(logger.msgs, expected) {
+		t.Fatalf("expected warnings %q, got: %q", expected, logger.msgs)
+	}
+
+	t.Run("with too many deprecated endpoints", func(t *testing.T) {
+		logger.msgs = nil
+		for i := 0; i < maxDeprecationWarnings; i++ {
+			client.deprecationWarnings[fmt.Sprintf("GET /api/v2/endpoint-%d", i)] = true
+		}
+
+		req, err := client.newRequest("GET", "organizations/deprecated/workspaces", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(ctx, req, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(logger.msgs) != 0 {
+			t.Fatalf("expected no warnings, got: %q", logger.msgs)
+		}
+	})
+
+	t.Run("without a logger", func(t *testing.T) {
+		client, err := NewClient(&Config{
+			Address:    ts.URL,
+			Token:      "dummy-token",
+			HTTPClient: ts.Client(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req, err := client.newRequest("GET", "organizations/deprecated", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(ctx, req, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	tests := map[string]string{
+		"ping":                                 "ping",
+		"organizations/my-org":                 "organizations/:id",
+		"organizations/my-org/workspaces/ws":   "organizations/:id/workspaces/:id",
+		"workspaces/ws-123/actions/lock":       "workspaces/:id/actions/lock",
+		"workspaces/ws-123/relationships/vars": "workspaces/:id/relationships/vars",
+		"admin/organizations/my-org":           "admin/organizations/:id",
+		"workspaces/ws-123/vars/var-456":       "workspaces/:id/vars/:id",
+	}
+
+	for path, expected := range tests {
+		if got := endpointTemplate(path); got != expected {
+			t.Errorf("expected %q for %q, got: %q", expected, path, got)
+		}
+	}
+}
+
+func TestClient_pathEscape(t *testing.T) {
+	var requestURIs []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		requestURIs = append(requestURIs, r.RequestURI)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.WriteHeader(200)
+		w.Write([]byte(`{"data": {"type": "workspaces", "id": "ws-123"}}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	t.Run("with valid names", func(t *testing.T) {
+		if _, err := client.Workspaces.Read(ctx, "my-org", "my.workspace_1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []string{"/api/v2/organizations/my-org/workspaces/my.workspace_1"}
+		if !reflect.DeepEqual(requestURIs, expected) {
+			t.Fatalf("expected request URIs %q, got: %q", expected, requestURIs)
+		}
+	})
+
+	t.Run("with names that need escaping", func(t *testing.T) {
+		requestURIs = nil
+
+		for _, name := range []string{"bar baz", "foo?bar", "foo#bar", "100%"} {
+			if _, err := client.Workspaces.Read(ctx, "my-org", name); err != nil {
+				t.Fatalf("unexpected error for %q: %v", name, err)
+			}
+		}
+
+		expected := []string{
+			"/api/v2/organizations/my-org/workspaces/bar%20baz",
+			"/api/v2/organizations/my-org/workspaces/foo%3Fbar",
+			"/api/v2/organizations/my-org/workspaces/foo%23bar",
+			"/api/v2/organizations/my-org/workspaces/100%25",
+		}
+		if !reflect.DeepEqual(requestURIs, expected) {
+			t.Fatalf("expected request URIs %q, got: %q", expected, requestURIs)
+		}
+	})
+
+	t.Run("with names that would change the path", func(t *testing.T) {
+		requestURIs = nil
+
+		for _, name := range []string{"..", ".", "../foo", "foo/bar", `foo\bar`, "foo\nbar"} {
+			_, err := client.Workspaces.Read(ctx, "my-org", name)
+			if err == nil || err.Error() != "invalid value for workspace" {
+				t.Fatalf("expected an invalid value error for %q, got: %v", name, err)
+			}
+		}
+
+		if len(requestURIs) != 0 {
+			t.Fatalf("expected no requests, got: %q", requestURIs)
+		}
+	})
+}
+
+func TestClient_requestSigner(t *testing.T) {
+	var requests int32
+	var signature, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		signature = r.Header.Get("X-Signature")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	var signErr error
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		RequestSigner: func(req *http.Request) error {
+			if signErr != nil {
+				return signErr
+			}
+			var b []byte
+			if req.Body != nil {
+				b, _ = ioutil.ReadAll(req.Body)
+			}
+			req.Header.Set("X-Signature", testSignature(req.Method, req.URL.Path, b))
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("signs the final body", func(t *testing.T) {
+		req, err := client.newRequest("PATCH", "workspaces/ws-123", &WorkspaceUpdateOptions{
+			Name: String("foo"),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(context.Background(), req, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if body == "" {
+			t.Fatal("expected the request body to be sent")
+		}
+		expected := testSignature("PATCH", "/api/v2/workspaces/ws-123", []byte(body))
+		if signature != expected {
+			t.Fatalf("expected signature %q, got: %q", expected, signature)
+		}
+	})
+
+	t.Run("does not send the request when signing fails", func(t *testing.T) {
+		signErr = errors.New("signing failed")
+		defer func() { signErr = nil }()
+
+		before := atomic.LoadInt32(&requests)
+
+		req, err := client.newRequest("GET", "workspaces/ws-123", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(context.Background(), req, nil); err != signErr {
+			t.Fatalf("expected error %v, got: %v", signErr, err)
+		}
+
+		if atomic.LoadInt32(&requests) != before {
+			t.Fatal("expected the request not to be sent")
+		}
+	})
+}
+
+func testSignature(method, path string, body []byte) string {
+	sum := sha256.Sum256(append([]byte(method+" "+path+" "), body...))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestClient_captureFailedRequests(t *testing.T) {
+	var requests int32
+	var authorization, custom, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		authorization = r.Header.Get("Authorization")
+		custom = r.Header.Get("X-Custom")
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(404)
+	}))
+	defer ts.Close()
+
+	headers := make(http.Header)
+	headers.Set("X-Custom", "custom-secret")
+
+	logger := &testLogger{}
+	client, err := NewClient(&Config{
+		Address:               ts.URL,
+		Token:                 "dummy-token",
+		Headers:               headers,
+		HTTPClient:            ts.Client(),
+		Logger:                logger,
+		CaptureFailedRequests: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Updates of sensitive variables usually only send the new value,
+	// without marking it as sensitive.
+	req, err := client.newRequest("PATCH", "vars/var-123", &VariableUpdateOptions{
+		Key:   String("TOKEN"),
+		Value: String("hidden"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.do(context.Background(), req, nil)
+	if !errors.Is(err, ErrResourceNotFound) {
+		t.Fatalf("expected error %v, got: %v", ErrResourceNotFound, err)
+	}
+
+	var captureErr *CapturedRequestError
+	if !errors.As(err, &captureErr) {
+		t.Fatalf("expected a *CapturedRequestError, got: %T", err)
+	}
+
+	captured := captureErr.Request
+	if captured.Method != "PATCH" || !strings.HasSuffix(captured.URL, "/api/v2/vars/var-123") {
+		t.Fatalf("unexpected captured request: %s %s", captured.Method, captured.URL)
+	}
+	for _, h := range []string{"Authorization", "X-Custom"} {
+		if v := captured.Header.Get(h); v != "REDACTED" {
+			t.Fatalf("expected the %s header to be redacted, got: %q", h, v)
+		}
+	}
+	if v := captured.Header.Get("Content-Type"); v != "application/vnd.api+json" {
+		t.Fatalf("expected the Content-Type header to be captured, got: %q", v)
+	}
+	if strings.Contains(captured.Body, "hidden") || !strings.Contains(captured.Body, `"value":"REDACTED"`) {
+		t.Fatalf("expected the value to be redacted, got: %s", captured.Body)
+	}
+
+	t.Run("refuses to replay redacted values", func(t *testing.T) {
+		before := atomic.LoadInt32(&requests)
+
+		err := client.Replay(context.Background(), captured, ReplayOptions{})
+		if err == nil || !strings.Contains(err.Error(), "redacted values") {
+			t.Fatalf("expected a redacted values error, got: %v", err)
+		}
+		if atomic.LoadInt32(&requests) != before {
+			t.Fatal("expected the request not to be sent")
+		}
+	})
+
+	t.Run("replays redacted values when allowed", func(t *testing.T) {
+		err := client.Replay(context.Background(), captured, ReplayOptions{AllowRedacted: true})
+		if err != ErrResourceNotFound {
+			t.Fatalf("expected error %v, got: %v", ErrResourceNotFound, err)
+		}
+		if authorization != "Bearer dummy-token" {
+			t.Fatalf("expected the replayed request to be authenticated, got: %q", authorization)
+		}
+		if custom != "custom-secret" {
+			t.Fatalf("expected the default headers to be sent, got: %q", custom)
+		}
+		if body != captured.Body {
+			t.Fatalf("expected the captured body to be replayed, got: %s", body)
+		}
+
+		logger.mu.Lock()
+		defer logger.mu.Unlock()
+		if len(logger.msgs) != 2 {
+			t.Fatalf("expected the request and response to be logged, got: %v", logger.msgs)
+		}
+		for _, msg := range logger.msgs {
+			if strings.Contains(msg, "dummy-token") || strings.Contains(msg, "custom-secret") {
+				t.Fatalf("expected no secrets to be logged, got: %s", msg)
+			}
+		}
+	})
+
+	t.Run("refuses to replay when the client is closed", func(t *testing.T) {
+		closed, err := NewClient(&Config{
+			Address:    ts.URL,
+			Token:      "dummy-token",
+			HTTPClient: ts.Client(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		closed.Close()
+
+		err = closed.Replay(context.Background(), &CapturedRequest{Method: "GET", URL: ts.URL + "/api/v2/foo"}, ReplayOptions{})
+		if err != ErrClientClosed {
+			t.Fatalf("expected error %v, got: %v", ErrClientClosed, err)
+		}
+	})
+}
+
+func TestClient_clockSkew(t *testing.T) {
+	var date string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if date != "" {
+			w.Header()["Date"] = []string{date}
+		}
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("with a server clock ahead", func(t *testing.T) {
+		date = time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+		skew, err := client.ClockSkew(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if skew < 59*time.Minute || skew > 61*time.Minute {
+			t.Fatalf("expected a skew of about an hour, got: %v", skew)
+		}
+	})
+
+	t.Run("with an RFC 850 date", func(t *testing.T) {
+		date = "Sunday, 06-Nov-94 08:49:37 GMT"
+
+		serverTime, err := client.ServerTime(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := time.Date(1994, time.November, 6, 8, 49, 37, 0, time.UTC)
+		if !serverTime.Equal(expected) {
+			t.Fatalf("expected server time %v, got: %v", expected, serverTime)
+		}
+	})
+
+	t.Run("with an invalid date", func(t *testing.T) {
+		date = "yesterday"
+
+		_, err := client.ServerTime(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "invalid Date header") {
+			t.Fatalf("expected an invalid Date header error, got: %v", err)
+		}
+	})
+}
+
+func TestClient_onRetry(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		if r.URL.Path == "/api/v2/limited" {
+			w.Header().Set("X-RateLimit-Reset", "0.01")
+			w.WriteHeader(429)
+			return
+		}
+		if atomic.AddInt32(&requests, 1)%3 != 0 {
+			w.Header().Set("X-RateLimit-Reset", "0.01")
+			w.WriteHeader(429)
+			return
+		}
+		w.WriteHeader(204)
+	}))
+	defer ts.Close()
+
+	var attempts []int
+	allow := true
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+		OnRetry: func(attempt int, resp *http.Response, err error) bool {
+			attempts = append(attempts, attempt)
+			if resp == nil || resp.StatusCode != 429 {
+				t.Fatalf("expected a 429 response, got: %v (error: %v)", resp, err)
+			}
+			return allow
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("when retries are allowed", func(t *testing.T) {
+		req, err := client.newRequest("GET", "foo", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(context.Background(), req, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(attempts, []int{1, 2}) {
+			t.Fatalf("expected retry attempts [1 2], got: %v", attempts)
+		}
+	})
+
+	t.Run("when retries are vetoed", func(t *testing.T) {
+		attempts, allow = nil, false
+		before := atomic.LoadInt32(&requests)
+
+		req, err := client.newRequest("GET", "foo", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(context.Background(), req, nil); err == nil {
+			t.Fatal("expected an error when retries are vetoed")
+		}
+		if !reflect.DeepEqual(attempts, []int{1}) {
+			t.Fatalf("expected retry attempts [1], got: %v", attempts)
+		}
+		if n := atomic.LoadInt32(&requests) - before; n != 1 {
+			t.Fatalf("expected a single request, got: %d", n)
+		}
+	})
+
+	t.Run("when the retries are exhausted", func(t *testing.T) {
+		attempts, allow = nil, true
+		client.http.RetryMax = 2
+
+		req, err := client.newRequest("GET", "limited", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := client.do(context.Background(), req, nil); err == nil {
+			t.Fatal("expected an error when the retries are exhausted")
+		}
+		if !reflect.DeepEqual(attempts, []int{1, 2}) {
+			t.Fatalf("expected retry attempts [1 2], got: %v", attempts)
+		}
+	})
+
+	t.Run("when a captured request is replayed", func(t *testing.T) {
+		attempts, allow = nil, true
+		client.http.RetryMax = 2
+		client.logger = &testLogger{}
+
+		captured := &CapturedRequest{Method: "GET", URL: ts.URL + "/api/v2/limited"}
+		if err := client.Replay(context.Background(), captured, ReplayOptions{}); err == nil {
+			t.Fatal("expected an error when the retries are exhausted")
+		}
+		if !reflect.DeepEqual(attempts, []int{1, 2}) {
+			t.Fatalf("expected retry attempts [1 2], got: %v", attempts)
+		}
+	})
+}
@@ -5,6 +5,11 @@ func Access(v AccessType) *AccessType {
 	return &v
 }
 
+// ProjectAccess returns a pointer to the given team project access type.
+func ProjectAccess(v TeamProjectAccessType) *TeamProjectAccessType {
+	return &v
+}
+
 // AuthPolicy returns a pointer to the given authentication poliy.
 func AuthPolicy(v AuthPolicyType) *AuthPolicyType {
 	return &v
@@ -35,6 +40,12 @@ func Int64(v int64) *int64 {
 	return &v
 }
 
+// NotificationDestination returns a pointer to the given notification
+// destination type.
+func NotificationDestination(v NotificationDestinationType) *NotificationDestinationType {
+	return &v
+}
+
 // ServiceProvider returns a pointer to the given service provider type.
 func ServiceProvider(v ServiceProviderType) *ServiceProviderType {
 	return &v
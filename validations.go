@@ -1,19 +1,72 @@
 package tfe
 
 import (
+	"net/url"
+	"path"
 	"regexp"
+	"strings"
 )
 
 // A regular expression used to validate common string ID patterns.
 var reStringID = regexp.MustCompile(`^[a-zA-Z0-9\-\._]+$`)
 
+// A regular expression used to validate path segments, which can contain
+// any character except slashes and control characters.
+var rePathSegment = regexp.MustCompile(`^[^/\\\x00-\x1f\x7f]+$`)
+
+// A regular expression used to validate tag names.
+var reTagName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9:_\-]*$`)
+
 // validString checks if the given input is present and non-empty.
 func validString(v *string) bool {
 	return v != nil && *v != ""
 }
 
 // validStringID checks if the given string pointer is non-nil and
-// contains a typical string identifier.
+// contains a typical string identifier. The dot segments "." and ".." are
+// not valid identifiers, as they would change the path they are used in.
 func validStringID(v *string) bool {
-	return v != nil && reStringID.MatchString(*v)
+	return v != nil && *v != "." && *v != ".." && reStringID.MatchString(*v)
+}
+
+// validPathSegment checks if the given string pointer is non-nil and
+// contains a name that can be used as a single path segment once escaped
+// with url.PathEscape. Unlike validStringID it allows spaces and other
+// special characters, as resources keyed by a name can contain them.
+func validPathSegment(v *string) bool {
+	return v != nil && *v != "." && *v != ".." && rePathSegment.MatchString(*v)
+}
+
+// validRelativePath checks if the given string pointer contains a relative
+// path that stays within its root. An empty path refers to the root itself.
+func validRelativePath(v *string) bool {
+	if v == nil {
+		return false
+	}
+	p := strings.Replace(*v, `\`, "/", -1)
+	if path.IsAbs(p) || strings.Contains(p, ":") {
+		return false
+	}
+	c := path.Clean(p)
+	return c != ".." && !strings.HasPrefix(c, "../")
+}
+
+// validTagName checks if the given string is a valid tag name. Tag names
+// can contain letters, numbers, colons, hyphens and underscores, and are
+// at most 255 characters long.
+func validTagName(v string) bool {
+	return len(v) <= 255 && reTagName.MatchString(v)
+}
+
+// validURL checks if the given string pointer contains an absolute http or
+// https URL.
+func validURL(v *string) bool {
+	if v == nil {
+		return false
+	}
+	u, err := url.Parse(*v)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
 }
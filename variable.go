@@ -1,10 +1,14 @@
 package tfe
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"time"
 )
 
 // Compile-time proof of interface implementation.
@@ -18,6 +22,10 @@ type Variables interface {
 	// List all the variables associated with the given workspace.
 	List(ctx context.Context, options VariableListOptions) (*VariableList, error)
 
+	// ListMap lists all the variables associated with the given workspace,
+	// keyed by their ID.
+	ListMap(ctx context.Context, options VariableListOptions) (map[string]*Variable, error)
+
 	// Create is used to create a new variable.
 	Create(ctx context.Context, options VariableCreateOptions) (*Variable, error)
 
@@ -29,6 +37,48 @@ type Variables interface {
 
 	// Delete a variable by its ID.
 	Delete(ctx context.Context, variableID string) error
+
+	// ExportTfvars exports the Terraform variables of a workspace in the
+	// .tfvars format.
+	ExportTfvars(ctx context.Context, options VariableListOptions) ([]byte, error)
+
+	// ExportDotenv exports the environment variables of a workspace in the
+	// dotenv format.
+	ExportDotenv(ctx context.Context, options VariableListOptions) ([]byte, error)
+
+	// ImportTfvars creates Terraform variables from a .tfvars file.
+	ImportTfvars(ctx context.Context, workspaceID string, data []byte) ([]*Variable, error)
+
+	// ImportDotenv creates environment variables from a dotenv file.
+	ImportDotenv(ctx context.Context, workspaceID string, data []byte) ([]*Variable, error)
+
+	// ImportFile creates variables from a YAML or JSON variable file.
+	ImportFile(ctx context.Context, workspaceID string, data []byte, format string) ([]*Variable, error)
+
+	// SchemaJSON describes the variables of a workspace as a JSON Schema.
+	SchemaJSON(ctx context.Context, options VariableListOptions) ([]byte, error)
+
+	// CheckHCL checks the syntax of the HCL variables of a workspace.
+	CheckHCL(ctx context.Context, options VariableListOptions) ([]*HCLCheckResult, error)
+
+	// Export returns the variables of a workspace in a portable form.
+	Export(ctx context.Context, options VariableListOptions) ([]VariableExport, error)
+
+	// StreamOrganization streams the variables of all workspaces of an
+	// organization.
+	StreamOrganization(ctx context.Context, organization string) (<-chan VariableWithWorkspace, <-chan error)
+
+	// FindByKey finds the variables with the given key in all workspaces of
+	// an organization.
+	FindByKey(ctx context.Context, organization string, key string) ([]VariableWithWorkspace, error)
+
+	// StaleSensitive lists the sensitive variables of a workspace that were
+	// not updated recently.
+	StaleSensitive(ctx context.Context, workspaceID string, olderThan time.Duration) ([]*Variable, error)
+
+	// Summary counts the variables of a workspace by category, without
+	// retrieving their values.
+	Summary(ctx context.Context, options VariableListOptions) (*VariableSummary, error)
 }
 
 // variables implements Variables.
@@ -53,15 +103,21 @@ type VariableList struct {
 
 // Variable represents a Terraform Enterprise variable.
 type Variable struct {
-	ID        string       `jsonapi:"primary,vars"`
-	Key       string       `jsonapi:"attr,key"`
-	Value     string       `jsonapi:"attr,value"`
-	Category  CategoryType `jsonapi:"attr,category"`
-	HCL       bool         `jsonapi:"attr,hcl"`
-	Sensitive bool         `jsonapi:"attr,sensitive"`
+	ID          string       `jsonapi:"primary,vars"`
+	Key         string       `jsonapi:"attr,key"`
+	Value       string       `jsonapi:"attr,value"`
+	Description string       `jsonapi:"attr,description"`
+	Category    CategoryType `jsonapi:"attr,category"`
+	HCL         bool         `jsonapi:"attr,hcl"`
+	Sensitive   bool         `jsonapi:"attr,sensitive"`
+
+	// The time the variable was last updated. This is zero when the API
+	// does not report it.
+	UpdatedAt time.Time `jsonapi:"attr,updated-at,iso8601"`
 
 	// Relations
-	Workspace *Workspace `jsonapi:"relation,workspace"`
+	VariableSet *VariableSet `jsonapi:"relation,varset"`
+	Workspace   *Workspace   `jsonapi:"relation,workspace"`
 }
 
 // VariableListOptions represents the options for listing variables.
@@ -94,11 +150,31 @@ func (s *variables) List(ctx context.Context, options VariableListOptions) (*Var
 
 	vl := &VariableList{}
 	err = s.client.do(ctx, req, vl)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	return vl, err
+}
+
+// ListMap lists all the variables associated with the given workspace,
+// following all pages, and returns them keyed by their ID.
+func (s *variables) ListMap(ctx context.Context, options VariableListOptions) (map[string]*Variable, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	vars, err := s.listAll(ctx, options)
 	if err != nil {
 		return nil, err
 	}
 
-	return vl, nil
+	vm := make(map[string]*Variable, len(vars))
+	for _, v := range vars {
+		vm[v.ID] = v
+	}
+
+	return vm, nil
 }
 
 // VariableCreateOptions represents the options for creating a new variable.
@@ -121,6 +197,9 @@ type VariableCreateOptions struct {
 	// Whether the value is sensitive.
 	Sensitive *bool `jsonapi:"attr,sensitive,omitempty"`
 
+	// The description of the variable.
+	Description *string `jsonapi:"attr,description,omitempty"`
+
 	// The workspace that owns the variable.
 	Workspace *Workspace `jsonapi:"relation,workspace"`
 }
@@ -170,7 +249,7 @@ func (s *variables) Read(ctx context.Context, variableID string) (*Variable, err
 		return nil, errors.New("invalid value for variable ID")
 	}
 
-	u := fmt.Sprintf("vars/%s", url.QueryEscape(variableID))
+	u := fmt.Sprintf("vars/%s", url.PathEscape(variableID))
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
 		return nil, err
@@ -212,7 +291,7 @@ func (s *variables) Update(ctx context.Context, variableID string, options Varia
 	// Make sure we don't send a user provided ID.
 	options.ID = variableID
 
-	u := fmt.Sprintf("vars/%s", url.QueryEscape(variableID))
+	u := fmt.Sprintf("vars/%s", url.PathEscape(variableID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -233,7 +312,7 @@ func (s *variables) Delete(ctx context.Context, variableID string) error {
 		return errors.New("invalid value for variable ID")
 	}
 
-	u := fmt.Sprintf("vars/%s", url.QueryEscape(variableID))
+	u := fmt.Sprintf("vars/%s", url.PathEscape(variableID))
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
 		return err
@@ -241,3 +320,483 @@ func (s *variables) Delete(ctx context.Context, variableID string) error {
 
 	return s.client.do(ctx, req, nil)
 }
+
+// listAll retrieves all the variables associated with the given workspace by
+// walking through all available pages.
+func (s *variables) listAll(ctx context.Context, options VariableListOptions) ([]*Variable, error) {
+	var vars []*Variable
+	for {
+		vl, err := s.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		vars = append(vars, vl.Items...)
+
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = vl.NextPage
+	}
+
+	return vars, nil
+}
+
+// ExportTfvars exports all the Terraform variables of the given workspace in
+// the .tfvars format. HCL variables are written as-is, all other values are
+// written as quoted strings. Sensitive variables are omitted from the output
+// and replaced by a comment noting they were skipped.
+func (s *variables) ExportTfvars(ctx context.Context, options VariableListOptions) ([]byte, error) {
+	vars, err := s.listAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for _, v := range sortedVariables(vars, CategoryTerraform) {
+		switch {
+		case v.Sensitive:
+			fmt.Fprintf(buf, "# %s omitted: sensitive value\n", v.Key)
+		case v.HCL:
+			fmt.Fprintf(buf, "%s = %s\n", v.Key, v.Value)
+		default:
+			fmt.Fprintf(buf, "%s = %s\n", v.Key, quoteHCLString(v.Value))
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExportDotenv exports all the environment variables of the given workspace
+// in the dotenv format. Sensitive variables are omitted from the output and
+// replaced by a comment noting they were skipped.
+func (s *variables) ExportDotenv(ctx context.Context, options VariableListOptions) ([]byte, error) {
+	vars, err := s.listAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for _, v := range sortedVariables(vars, CategoryEnv) {
+		if v.Sensitive {
+			fmt.Fprintf(buf, "# %s omitted: sensitive value\n", v.Key)
+			continue
+		}
+		fmt.Fprintf(buf, "%s=%s\n", v.Key, quoteDotenvString(v.Value))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sortedVariables returns the variables of the given category sorted by key.
+func sortedVariables(vars []*Variable, category CategoryType) []*Variable {
+	var result []*Variable
+	for _, v := range vars {
+		if v.Category == category {
+			result = append(result, v)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// ImportTfvars parses the given .tfvars file and creates a Terraform variable
+// in the given workspace for every assignment. Lists, maps and any other HCL
+// expressions are created as HCL variables. Parse errors are reported with
+// the offending line number and, like empty values which cannot be created,
+// prevent any variable from being created. If
+// creating a variable fails, the variables created so far are returned
+// together with the error.
+func (s *variables) ImportTfvars(ctx context.Context, workspaceID string, data []byte) ([]*Variable, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	options, err := parseTfvars(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createAll(ctx, workspaceID, options)
+}
+
+// ImportDotenv parses the given dotenv file and creates an environment
+// variable in the given workspace for every assignment. Parse errors are
+// reported with the offending line number and, like empty values which
+// cannot be created, prevent any variable from being created. If creating a
+// variable fails, the variables created so far are returned together with
+// the error.
+func (s *variables) ImportDotenv(ctx context.Context, workspaceID string, data []byte) ([]*Variable, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	options, err := parseDotenv(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createAll(ctx, workspaceID, options)
+}
+
+// ImportFile parses the given YAML or JSON variable file, see
+// ParseVariableFile, and creates each variable in the given workspace.
+// Schema errors are reported as a *VariableFileError and prevent any
+// variable from being created. If creating a variable fails, the variables
+// created so far are returned together with the error.
+func (s *variables) ImportFile(ctx context.Context, workspaceID string, data []byte, format string) ([]*Variable, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	options, err := ParseVariableFile(data, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createAll(ctx, workspaceID, options)
+}
+
+// createAll creates a variable in the given workspace for each of the given
+// options, stopping at the first error. All options are validated before
+// any variable is created.
+func (s *variables) createAll(ctx context.Context, workspaceID string, options []VariableCreateOptions) ([]*Variable, error) {
+	for i := range options {
+		options[i].Workspace = &Workspace{ID: workspaceID}
+		if err := options[i].valid(); err != nil {
+			return nil, fmt.Errorf("invalid variable %q: %v", *options[i].Key, err)
+		}
+	}
+
+	var vars []*Variable
+	for _, o := range options {
+		v, err := s.Create(ctx, o)
+		if err != nil {
+			return vars, fmt.Errorf("error creating variable %q: %v", *o.Key, err)
+		}
+
+		vars = append(vars, v)
+	}
+
+	return vars, nil
+}
+
+// variableSchema is a (partial) JSON Schema used to describe variables.
+type variableSchema struct {
+	Schema      string                     `json:"$schema,omitempty"`
+	Type        string                     `json:"type"`
+	Description string                     `json:"description,omitempty"`
+	Properties  map[string]*variableSchema `json:"properties,omitempty"`
+	Default     *string                    `json:"default,omitempty"`
+	WriteOnly   bool                       `json:"writeOnly,omitempty"`
+
+	// Extensions describing the variable settings.
+	Category  CategoryType `json:"x-tfe-category,omitempty"`
+	HCL       *bool        `json:"x-tfe-hcl,omitempty"`
+	Sensitive *bool        `json:"x-tfe-sensitive,omitempty"`
+}
+
+// SchemaJSON returns a JSON Schema document describing the variables of the
+// given workspace, which can be used to render a form for the variables. The
+// document holds a "terraform" and an "env" object, each with a property per
+// variable of that category. The current value of a variable is used as its
+// default, except for sensitive variables which are only flagged as such.
+func (s *variables) SchemaJSON(ctx context.Context, options VariableListOptions) ([]byte, error) {
+	vars, err := s.listAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &variableSchema{
+		Schema: "http://json-schema.org/draft-07/schema#",
+		Type:   "object",
+		Properties: map[string]*variableSchema{
+			string(CategoryTerraform): {Type: "object", Properties: map[string]*variableSchema{}},
+			string(CategoryEnv):       {Type: "object", Properties: map[string]*variableSchema{}},
+		},
+	}
+
+	for _, v := range vars {
+		category, ok := schema.Properties[string(v.Category)]
+		if !ok {
+			continue
+		}
+
+		property := &variableSchema{
+			Type:        "string",
+			Description: v.Description,
+			WriteOnly:   v.Sensitive,
+			Category:    v.Category,
+			HCL:         Bool(v.HCL),
+			Sensitive:   Bool(v.Sensitive),
+		}
+		if !v.Sensitive {
+			property.Default = String(v.Value)
+		}
+
+		category.Properties[v.Key] = property
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// HCLCheckResult holds the result of checking the syntax of an HCL variable.
+type HCLCheckResult struct {
+	Variable *Variable
+
+	// The syntax error found in the value, if any.
+	Error *HCLSyntaxError
+}
+
+// Valid reports whether the value of the variable is valid.
+func (r *HCLCheckResult) Valid() bool {
+	return r.Error == nil
+}
+
+// CheckHCL checks the syntax of the value of each HCL variable of the given
+// workspace and returns a result for each of them, sorted by key. Sensitive
+// variables are skipped as their values cannot be read.
+func (s *variables) CheckHCL(ctx context.Context, options VariableListOptions) ([]*HCLCheckResult, error) {
+	vars, err := s.listAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*HCLCheckResult
+	for _, v := range sortedVariables(vars, CategoryTerraform) {
+		if !v.HCL || v.Sensitive {
+			continue
+		}
+
+		result = append(result, &HCLCheckResult{
+			Variable: v,
+			Error:    checkHCLSyntax(v.Value),
+		})
+	}
+
+	return result, nil
+}
+
+// VariableWithWorkspace represents a variable together with its workspace.
+type VariableWithWorkspace struct {
+	Variable  *Variable
+	Workspace *Workspace
+}
+
+// StreamOrganization walks through all the workspaces of the given
+// organization and sends each of their variables on the returned channel.
+// Only one page of variables is held in memory at a time, and the next page
+// is only requested once the previous one has been consumed. If walking the
+// organization fails, the context is canceled or the client is closed, the
+// error is sent on the error channel. Both channels are closed when the walk
+// is done.
+func (s *variables) StreamOrganization(ctx context.Context, organization string) (<-chan VariableWithWorkspace, <-chan error) {
+	vc := make(chan VariableWithWorkspace)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(vc)
+		defer close(errc)
+
+		// Stop the walk when the client is closed.
+		ctx, cancel := s.client.closeContext(ctx)
+		defer cancel()
+
+		if err := s.streamOrganization(ctx, organization, vc); err != nil {
+			if s.client.isClosed() {
+				err = ErrClientClosed
+			}
+			errc <- err
+		}
+	}()
+
+	return vc, errc
+}
+
+func (s *variables) streamOrganization(ctx context.Context, organization string, vc chan<- VariableWithWorkspace) error {
+	if !validStringID(&organization) {
+		return errors.New("invalid value for organization")
+	}
+
+	wsOptions := WorkspaceListOptions{}
+	for {
+		wl, err := s.client.Workspaces.List(ctx, organization, wsOptions)
+		if err != nil {
+			return err
+		}
+
+		for _, w := range wl.Items {
+			options := VariableListOptions{
+				Organization: String(organization),
+				Workspace:    String(w.Name),
+			}
+
+			for {
+				vl, err := s.List(ctx, options)
+				if err != nil {
+					return err
+				}
+
+				for _, v := range vl.Items {
+					select {
+					case vc <- VariableWithWorkspace{Variable: v, Workspace: w}:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+
+				if vl.Pagination == nil || vl.NextPage == 0 {
+					break
+				}
+				options.PageNumber = vl.NextPage
+			}
+		}
+
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		wsOptions.PageNumber = wl.NextPage
+	}
+
+	return nil
+}
+
+// FindByKey finds the variables with the given key in all workspaces of the
+// given organization, sorted by workspace name. The variables are collected
+// using StreamOrganization, so only the matching variables are kept in
+// memory. If walking the organization fails, the error is returned.
+func (s *variables) FindByKey(ctx context.Context, organization string, key string) ([]VariableWithWorkspace, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+	if !validString(&key) {
+		return nil, errors.New("key is required")
+	}
+
+	var found []VariableWithWorkspace
+
+	vc, errc := s.StreamOrganization(ctx, organization)
+	for vw := range vc {
+		if vw.Variable.Key == key {
+			found = append(found, vw)
+		}
+	}
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].Workspace.Name != found[j].Workspace.Name {
+			return found[i].Workspace.Name < found[j].Workspace.Name
+		}
+		return found[i].Variable.Category < found[j].Variable.Category
+	})
+
+	return found, nil
+}
+
+// StaleSensitive returns the sensitive variables of the given workspace that
+// were not updated within the given duration, sorted by key. The values of
+// sensitive variables are never returned by the API. Variables without an
+// update time are returned as well, as their age cannot be determined.
+func (s *variables) StaleSensitive(ctx context.Context, workspaceID string, olderThan time.Duration) ([]*Variable, error) {
+	// Variables are listed by workspace name, so read the workspace first.
+	w, err := s.client.readWorkspaceByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if w.Organization == nil {
+		return nil, fmt.Errorf("workspace %s does not have an organization", workspaceID)
+	}
+
+	vars, err := s.listAll(ctx, VariableListOptions{
+		Organization: String(w.Organization.Name),
+		Workspace:    String(w.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+
+	var stale []*Variable
+	for _, v := range vars {
+		if v.Sensitive && (v.UpdatedAt.IsZero() || v.UpdatedAt.Before(cutoff)) {
+			stale = append(stale, v)
+		}
+	}
+
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Key < stale[j].Key
+	})
+
+	return stale, nil
+}
+
+// VariableSummary holds the number of variables of a workspace.
+type VariableSummary struct {
+	Total     int
+	Terraform int
+	Env       int
+	Sensitive int
+}
+
+// variableSummaryListOptions represents the options for listing the
+// variables to summarize. Only the attributes needed for the summary are
+// requested using a sparse fieldset.
+type variableSummaryListOptions struct {
+	VariableListOptions
+	Fields string `url:"fields[vars]"`
+}
+
+// Summary counts the variables of the given workspace by category and the
+// number of sensitive variables. Only the category and sensitive attributes
+// of the variables are retrieved, so no values are transferred.
+func (s *variables) Summary(ctx context.Context, options VariableListOptions) (*VariableSummary, error) {
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	listOptions := variableSummaryListOptions{
+		VariableListOptions: options,
+		Fields:              "category,sensitive",
+	}
+
+	summary := &VariableSummary{}
+	for {
+		req, err := s.client.newRequest("GET", "vars", &listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		vl := &VariableList{}
+		err = s.client.do(ctx, req, vl)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range vl.Items {
+			summary.Total++
+			switch v.Category {
+			case CategoryTerraform:
+				summary.Terraform++
+			case CategoryEnv:
+				summary.Env++
+			}
+			if v.Sensitive {
+				summary.Sensitive++
+			}
+		}
+
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		listOptions.PageNumber = vl.NextPage
+	}
+
+	return summary, nil
+}
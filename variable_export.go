@@ -0,0 +1,147 @@
+package tfe
+
+import (
+	"context"
+	"sort"
+)
+
+// VariableExport represents a variable in a portable form, which is not tied
+// to a specific workspace.
+type VariableExport struct {
+	Key         string       `json:"key"`
+	Value       string       `json:"value,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Category    CategoryType `json:"category"`
+	HCL         bool         `json:"hcl"`
+	Sensitive   bool         `json:"sensitive"`
+}
+
+// ExportDiff represents the differences between two sets of exported
+// variables.
+type ExportDiff struct {
+	Added   []VariableExport
+	Removed []VariableExport
+	Changed []*VariableChange
+}
+
+// Empty reports whether there are no differences.
+func (d *ExportDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// VariableChange represents a variable that exists in both sets of exported
+// variables, but with different settings. The values of sensitive variables
+// are never included.
+type VariableChange struct {
+	Key      string
+	Category CategoryType
+	Old      VariableExport
+	New      VariableExport
+}
+
+// Export returns the variables of the given workspace in a portable form,
+// sorted by category and key. The values of sensitive variables are empty.
+func (s *variables) Export(ctx context.Context, options VariableListOptions) ([]VariableExport, error) {
+	vars, err := s.listAll(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	exports := make([]VariableExport, 0, len(vars))
+	for _, v := range vars {
+		e := VariableExport{
+			Key:         v.Key,
+			Description: v.Description,
+			Category:    v.Category,
+			HCL:         v.HCL,
+			Sensitive:   v.Sensitive,
+		}
+		if !v.Sensitive {
+			e.Value = v.Value
+		}
+		exports = append(exports, e)
+	}
+
+	sortVariableExports(exports)
+
+	return exports, nil
+}
+
+// DiffVariableExports compares two sets of exported variables and returns the
+// variables that were added to, removed from or changed in b compared to a.
+// Variables are matched by category and key. As the values of sensitive
+// variables are not known, a sensitive variable is only reported as changed
+// when its settings differ, and its values are left out of the change.
+func DiffVariableExports(a, b []VariableExport) *ExportDiff {
+	type id struct {
+		category CategoryType
+		key      string
+	}
+
+	old := make(map[id]VariableExport, len(a))
+	for _, e := range a {
+		old[id{e.Category, e.Key}] = e
+	}
+
+	seen := make(map[id]bool, len(b))
+	diff := &ExportDiff{}
+
+	for _, e := range b {
+		k := id{e.Category, e.Key}
+		seen[k] = true
+
+		o, ok := old[k]
+		if !ok {
+			diff.Added = append(diff.Added, redactExport(e))
+			continue
+		}
+
+		sensitive := o.Sensitive || e.Sensitive
+		if o.HCL == e.HCL && o.Sensitive == e.Sensitive && o.Description == e.Description &&
+			(sensitive || o.Value == e.Value) {
+			continue
+		}
+
+		diff.Changed = append(diff.Changed, &VariableChange{
+			Key:      e.Key,
+			Category: e.Category,
+			Old:      redactExport(o),
+			New:      redactExport(e),
+		})
+	}
+
+	for _, e := range a {
+		if !seen[id{e.Category, e.Key}] {
+			diff.Removed = append(diff.Removed, redactExport(e))
+		}
+	}
+
+	sortVariableExports(diff.Added)
+	sortVariableExports(diff.Removed)
+	sort.Slice(diff.Changed, func(i, j int) bool {
+		if diff.Changed[i].Category != diff.Changed[j].Category {
+			return diff.Changed[i].Category < diff.Changed[j].Category
+		}
+		return diff.Changed[i].Key < diff.Changed[j].Key
+	})
+
+	return diff
+}
+
+// redactExport returns a copy of e without its value if it is sensitive.
+func redactExport(e VariableExport) VariableExport {
+	if e.Sensitive {
+		e.Value = ""
+	}
+	return e
+}
+
+// sortVariableExports sorts the exported variables by category and key.
+func sortVariableExports(exports []VariableExport) {
+	sort.Slice(exports, func(i, j int) bool {
+		if exports[i].Category != exports[j].Category {
+			return exports[i].Category < exports[j].Category
+		}
+		return exports[i].Key < exports[j].Key
+	})
+}
@@ -0,0 +1,66 @@
+package tfe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffVariableExports(t *testing.T) {
+	a := []VariableExport{
+		{Key: "region", Value: "eu-west-1", Category: CategoryTerraform},
+		{Key: "size", Value: "small", Category: CategoryTerraform},
+		{Key: "tags", Value: `{ env = "dev" }`, Category: CategoryTerraform, HCL: true},
+		{Key: "TOKEN", Value: "old-secret", Category: CategoryEnv, Sensitive: true},
+		{Key: "PASSWORD", Category: CategoryEnv, Sensitive: true},
+		{Key: "TF_LOG", Value: "debug", Category: CategoryEnv},
+	}
+
+	b := []VariableExport{
+		{Key: "region", Value: "us-east-1", Category: CategoryTerraform},
+		{Key: "size", Value: "small", Category: CategoryTerraform},
+		{Key: "tags", Value: `{ env = "dev" }`, Category: CategoryTerraform, HCL: true},
+		{Key: "TOKEN", Value: "new-secret", Category: CategoryEnv, Sensitive: true},
+		{Key: "PASSWORD", Value: "now-visible", Category: CategoryEnv},
+		{Key: "TF_LOG", Value: "debug", Category: CategoryTerraform},
+		{Key: "API_KEY", Value: "secret", Category: CategoryEnv, Sensitive: true},
+	}
+
+	t.Run("with differences", func(t *testing.T) {
+		diff := DiffVariableExports(a, b)
+		assert.False(t, diff.Empty())
+
+		assert.Equal(t, []VariableExport{
+			{Key: "API_KEY", Category: CategoryEnv, Sensitive: true},
+			{Key: "TF_LOG", Value: "debug", Category: CategoryTerraform},
+		}, diff.Added)
+
+		assert.Equal(t, []VariableExport{
+			{Key: "TF_LOG", Value: "debug", Category: CategoryEnv},
+		}, diff.Removed)
+
+		require.Len(t, diff.Changed, 2)
+
+		assert.Equal(t, "PASSWORD", diff.Changed[0].Key)
+		assert.Equal(t, CategoryEnv, diff.Changed[0].Category)
+		assert.Equal(t, "", diff.Changed[0].Old.Value)
+		assert.Equal(t, "now-visible", diff.Changed[0].New.Value)
+
+		assert.Equal(t, "region", diff.Changed[1].Key)
+		assert.Equal(t, "eu-west-1", diff.Changed[1].Old.Value)
+		assert.Equal(t, "us-east-1", diff.Changed[1].New.Value)
+	})
+
+	t.Run("without differences", func(t *testing.T) {
+		diff := DiffVariableExports(a, a)
+		assert.True(t, diff.Empty())
+	})
+
+	t.Run("with empty exports", func(t *testing.T) {
+		diff := DiffVariableExports(nil, b)
+		assert.Len(t, diff.Added, len(b))
+		assert.Empty(t, diff.Removed)
+		assert.Empty(t, diff.Changed)
+	})
+}
@@ -0,0 +1,779 @@
+package tfe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// A regular expression used to validate variable names in .tfvars files.
+var reHCLIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_\-]*$`)
+
+// A regular expression used to validate variable names in dotenv files.
+var reEnvName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// hclReplacer escapes the characters and template sequences that have a
+// special meaning inside a quoted HCL string.
+var hclReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+	"${", "$${",
+	"%{", "%%{",
+)
+
+// quoteHCLString returns a double-quoted HCL string literal representing v.
+func quoteHCLString(v string) string {
+	return `"` + hclReplacer.Replace(v) + `"`
+}
+
+// dotenvReplacer escapes the characters that have a special meaning inside
+// a double-quoted dotenv value.
+var dotenvReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+)
+
+// quoteDotenvString returns a double-quoted dotenv value representing v.
+func quoteDotenvString(v string) string {
+	return `"` + dotenvReplacer.Replace(v) + `"`
+}
+
+// splitLines splits data into lines, accepting both LF and CRLF endings.
+func splitLines(data []byte) []string {
+	return strings.Split(strings.Replace(string(data), "\r\n", "\n", -1), "\n")
+}
+
+// isComment reports whether the given (trimmed) text is a comment.
+func isComment(text string) bool {
+	return strings.HasPrefix(text, "#") || strings.HasPrefix(text, "//")
+}
+
+// parseTfvars parses the assignments in a .tfvars file into options for
+// creating Terraform variables. Quoted strings, heredocs, numbers and bools
+// are stored as plain values, while lists, maps and any other expressions
+// are stored as HCL.
+func parseTfvars(data []byte) ([]VariableCreateOptions, error) {
+	var result []VariableCreateOptions
+
+	lines := splitLines(data)
+	for i := 0; i < len(lines); i++ {
+		lineNum := i + 1
+
+		line := strings.TrimSpace(lines[i])
+		if line == "" || isComment(line) {
+			continue
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected an assignment", lineNum)
+		}
+
+		key := strings.TrimSpace(line[:eq])
+		if !reHCLIdentifier.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNum, key)
+		}
+
+		expr := strings.TrimSpace(line[eq+1:])
+		if expr == "" {
+			return nil, fmt.Errorf("line %d: missing value for %q", lineNum, key)
+		}
+
+		var value string
+		var hcl bool
+
+		switch {
+		case strings.HasPrefix(expr, `"`):
+			v, rest, err := unquoteHCLString(expr)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNum, err)
+			}
+			if rest = strings.TrimSpace(rest); rest != "" && !isComment(rest) {
+				return nil, fmt.Errorf("line %d: unexpected %q after value", lineNum, rest)
+			}
+			value = v
+
+		case strings.HasPrefix(expr, "<<"):
+			v, last, err := scanHeredoc(lines, i, expr)
+			if err != nil {
+				return nil, err
+			}
+			value, i = v, last
+
+		case expr[0] == '[' || expr[0] == '{':
+			v, last, err := scanHCLValue(lines, i, expr)
+			if err != nil {
+				return nil, err
+			}
+			value, hcl, i = v, true, last
+
+		default:
+			value = stripComment(expr)
+			if value == "" {
+				return nil, fmt.Errorf("line %d: missing value for %q", lineNum, key)
+			}
+			hcl = !isHCLLiteral(value)
+		}
+
+		result = append(result, VariableCreateOptions{
+			Key:      String(key),
+			Value:    String(value),
+			Category: Category(CategoryTerraform),
+			HCL:      Bool(hcl),
+		})
+	}
+
+	return result, nil
+}
+
+// unquoteHCLString parses the quoted HCL string at the start of expr and
+// returns its value together with the remaining text.
+func unquoteHCLString(expr string) (string, string, error) {
+	var b strings.Builder
+
+	for i := 1; i < len(expr); i++ {
+		c := expr[i]
+
+		switch {
+		case c == '"':
+			return b.String(), expr[i+1:], nil
+
+		case c == '\\':
+			if i+1 == len(expr) {
+				return "", "", fmt.Errorf("unterminated string")
+			}
+			i++
+			switch expr[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"':
+				b.WriteByte('"')
+			case '\\':
+				b.WriteByte('\\')
+			case 'u':
+				if i+4 >= len(expr) {
+					return "", "", fmt.Errorf("invalid unicode escape sequence")
+				}
+				r, err := strconv.ParseUint(expr[i+1:i+5], 16, 32)
+				if err != nil {
+					return "", "", fmt.Errorf("invalid unicode escape sequence")
+				}
+				b.WriteRune(rune(r))
+				i += 4
+			default:
+				return "", "", fmt.Errorf("invalid escape sequence \\%c", expr[i])
+			}
+
+		case (c == '$' || c == '%') && strings.HasPrefix(expr[i+1:], string(c)+"{"):
+			// Escaped template sequences ($${ and %%{) are literals.
+			b.WriteByte(c)
+			i++
+
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+// scanHeredoc reads the heredoc started by expr on line start and returns
+// its content and the index of the line holding the closing marker.
+func scanHeredoc(lines []string, start int, expr string) (string, int, error) {
+	marker := strings.TrimPrefix(expr, "<<")
+	indented := strings.HasPrefix(marker, "-")
+	marker = strings.TrimPrefix(marker, "-")
+
+	if !reHCLIdentifier.MatchString(marker) {
+		return "", 0, fmt.Errorf("line %d: invalid heredoc marker %q", start+1, marker)
+	}
+
+	var body []string
+	for i := start + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == marker {
+			if indented {
+				body = trimIndent(body)
+			}
+			return strings.Join(append(body, ""), "\n"), i, nil
+		}
+		body = append(body, lines[i])
+	}
+
+	return "", 0, fmt.Errorf("line %d: unterminated heredoc", start+1)
+}
+
+// trimIndent removes the common leading whitespace of all non-empty lines.
+func trimIndent(lines []string) []string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		result[i] = line
+	}
+
+	return result
+}
+
+// scanHCLValue reads the list or map value started by expr on line start,
+// which may span multiple lines. It returns the raw HCL (without comments)
+// and the index of the line on which the value ends.
+func scanHCLValue(lines []string, start int, expr string) (string, int, error) {
+	var b strings.Builder
+
+	depth := 0
+	inString := false
+	text := expr
+
+	for i := start; i < len(lines); i++ {
+		if i > start {
+			text = lines[i]
+			b.WriteByte('\n')
+		}
+
+	scan:
+		for j := 0; j < len(text); j++ {
+			c := text[j]
+
+			if inString {
+				b.WriteByte(c)
+				switch c {
+				case '\\':
+					if j+1 < len(text) {
+						j++
+						b.WriteByte(text[j])
+					}
+				case '"':
+					inString = false
+				}
+				continue
+			}
+
+			switch c {
+			case '"':
+				inString = true
+			case '#':
+				break scan
+			case '/':
+				if strings.HasPrefix(text[j:], "//") {
+					break scan
+				}
+			case '[', '{', '(':
+				depth++
+			case ']', '}', ')':
+				depth--
+			}
+			b.WriteByte(c)
+
+			if depth == 0 {
+				if rest := strings.TrimSpace(text[j+1:]); rest != "" && !isComment(rest) {
+					return "", 0, fmt.Errorf("line %d: unexpected %q after value", i+1, rest)
+				}
+				return b.String(), i, nil
+			}
+		}
+
+		if inString {
+			return "", 0, fmt.Errorf("line %d: unterminated string", i+1)
+		}
+	}
+
+	return "", 0, fmt.Errorf("line %d: unterminated value", start+1)
+}
+
+// stripComment removes a trailing comment from a single line expression.
+func stripComment(expr string) string {
+	inString := false
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case !inString && (c == '#' || strings.HasPrefix(expr[i:], "//")):
+			return strings.TrimSpace(expr[:i])
+		}
+	}
+	return expr
+}
+
+// isHCLLiteral reports whether expr is a number or bool literal, which
+// can be stored as a plain (non-HCL) value.
+func isHCLLiteral(expr string) bool {
+	if expr == "true" || expr == "false" {
+		return true
+	}
+	_, err := strconv.ParseFloat(expr, 64)
+	return err == nil
+}
+
+// parseDotenv parses the assignments in a dotenv file into options for
+// creating environment variables.
+func parseDotenv(data []byte) ([]VariableCreateOptions, error) {
+	var result []VariableCreateOptions
+
+	for i, line := range splitLines(data) {
+		lineNum := i + 1
+
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "export ") {
+			line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		}
+
+		eq := strings.Index(line, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		key := strings.TrimSpace(line[:eq])
+		if !reEnvName.MatchString(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNum, key)
+		}
+
+		raw := strings.TrimSpace(line[eq+1:])
+
+		var value, rest string
+		switch {
+		case strings.HasPrefix(raw, `"`):
+			v, r, err := unquoteDotenvString(raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNum, err)
+			}
+			value, rest = v, r
+
+		case strings.HasPrefix(raw, "'"):
+			end := strings.Index(raw[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated string", lineNum)
+			}
+			value, rest = raw[1:end+1], raw[end+2:]
+
+		default:
+			if idx := strings.Index(raw, " #"); idx >= 0 {
+				raw = raw[:idx]
+			}
+			value = strings.TrimSpace(raw)
+			if value == "" || strings.HasPrefix(value, "#") {
+				return nil, fmt.Errorf("line %d: missing value for %q", lineNum, key)
+			}
+		}
+
+		if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, fmt.Errorf("line %d: unexpected %q after value", lineNum, rest)
+		}
+
+		result = append(result, VariableCreateOptions{
+			Key:      String(key),
+			Value:    String(value),
+			Category: Category(CategoryEnv),
+		})
+	}
+
+	return result, nil
+}
+
+// unquoteDotenvString parses the double-quoted dotenv value at the start of
+// raw and returns its value together with the remaining text.
+func unquoteDotenvString(raw string) (string, string, error) {
+	var b strings.Builder
+
+	for i := 1; i < len(raw); i++ {
+		c := raw[i]
+
+		switch c {
+		case '"':
+			return b.String(), raw[i+1:], nil
+		case '\\':
+			if i+1 == len(raw) {
+				return "", "", fmt.Errorf("unterminated string")
+			}
+			i++
+			switch raw[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				b.WriteByte(raw[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", "", fmt.Errorf("unterminated string")
+}
+
+// HCLSyntaxError describes a syntax error found in an HCL value.
+type HCLSyntaxError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *HCLSyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+// checkHCLSyntax parses the given HCL expression and returns a description
+// of the first syntax error found, if any.
+func checkHCLSyntax(expr string) *HCLSyntaxError {
+	if strings.TrimSpace(expr) == "" {
+		return &HCLSyntaxError{Line: 1, Column: 1, Message: "empty expression"}
+	}
+
+	// A heredoc must be terminated by a newline after its closing marker,
+	// which values usually lack.
+	src := []byte(expr)
+	if !strings.HasSuffix(expr, "\n") {
+		src = append(src, '\n')
+	}
+
+	_, diags := hclsyntax.ParseExpression(src, "", hcl.Pos{Line: 1, Column: 1})
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+
+		err := &HCLSyntaxError{Line: 1, Column: 1, Message: diag.Summary}
+		if diag.Subject != nil {
+			err.Line = diag.Subject.Start.Line
+			err.Column = diag.Subject.Start.Column
+		}
+		return err
+	}
+
+	return nil
+}
+
+// VariableFileError describes a schema error found in a variable file. The
+// field is the path to the offending value, for example
+// "variables[2].category".
+type VariableFileError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *VariableFileError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ParseVariableFile parses a YAML or JSON variable file into options for
+// creating variables. The format is either "yaml" (or "yml") or "json". The
+// file holds a list of variables, for example:
+//
+//   variables:
+//     - key: region
+//       value: eu-west-1
+//       category: terraform
+//       description: The region to deploy to.
+//     - key: SECRET
+//       value: s3cr3t
+//       category: env
+//       sensitive: true
+//
+// The key, value and category of each variable are required, while the
+// description and the hcl and sensitive flags are optional. Numbers and
+// bools are accepted as values and used exactly as written in the file, so
+// a YAML value of 1.10 is "1.10" and not "1.1". All variables are validated
+// locally: keys must be valid Terraform or environment variable names, keys
+// must be unique per category, HCL values must be syntactically valid and
+// the options must be accepted by Variables.Create. The first problem found
+// is returned as a *VariableFileError. The returned options do not have a
+// workspace set; use Variables.ImportFile to create them in a workspace.
+func ParseVariableFile(data []byte, format string) ([]VariableCreateOptions, error) {
+	var doc interface{}
+
+	switch strings.ToLower(format) {
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, &VariableFileError{Message: fmt.Sprintf("invalid JSON: %v", err)}
+		}
+	case "yaml", "yml":
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return nil, &VariableFileError{Message: fmt.Sprintf("invalid YAML: %v", err)}
+		}
+		doc = yamlValue(&node)
+	default:
+		return nil, fmt.Errorf("unsupported variable file format %q", format)
+	}
+
+	root, err := fileObject("", doc)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFileFields("", root, "variables"); err != nil {
+		return nil, err
+	}
+
+	list, ok := root["variables"].([]interface{})
+	if !ok {
+		if root["variables"] == nil {
+			return nil, &VariableFileError{Field: "variables", Message: "is required"}
+		}
+		return nil, &VariableFileError{Field: "variables", Message: "must be a list"}
+	}
+
+	var result []VariableCreateOptions
+	seen := make(map[string]bool)
+
+	for i, item := range list {
+		field := fmt.Sprintf("variables[%d]", i)
+
+		v, err := fileObject(field, item)
+		if err != nil {
+			return nil, err
+		}
+		err = checkFileFields(field, v, "key", "value", "category", "description", "hcl", "sensitive")
+		if err != nil {
+			return nil, err
+		}
+
+		category, err := fileString(field+".category", v["category"], true)
+		if err != nil {
+			return nil, err
+		}
+		if category != string(CategoryTerraform) && category != string(CategoryEnv) {
+			return nil, &VariableFileError{
+				Field:   field + ".category",
+				Message: fmt.Sprintf("must be %q or %q", CategoryTerraform, CategoryEnv),
+			}
+		}
+
+		key, err := fileString(field+".key", v["key"], true)
+		if err != nil {
+			return nil, err
+		}
+		valid := reHCLIdentifier.MatchString(key)
+		if category == string(CategoryEnv) {
+			valid = reEnvName.MatchString(key)
+		}
+		if !valid {
+			return nil, &VariableFileError{
+				Field:   field + ".key",
+				Message: fmt.Sprintf("invalid variable name %q", key),
+			}
+		}
+		if seen[category+"/"+key] {
+			return nil, &VariableFileError{
+				Field:   field + ".key",
+				Message: fmt.Sprintf("duplicate %s variable %q", category, key),
+			}
+		}
+		seen[category+"/"+key] = true
+
+		value, err := fileString(field+".value", v["value"], false)
+		if err != nil {
+			return nil, err
+		}
+
+		description, err := fileString(field+".description", v["description"], false)
+		if err != nil {
+			return nil, err
+		}
+
+		hcl, err := fileBool(field+".hcl", v["hcl"])
+		if err != nil {
+			return nil, err
+		}
+		if hcl {
+			if err := checkHCLSyntax(value); err != nil {
+				return nil, &VariableFileError{
+					Field:   field + ".value",
+					Message: fmt.Sprintf("invalid HCL: %v", err),
+				}
+			}
+		}
+
+		sensitive, err := fileBool(field+".sensitive", v["sensitive"])
+		if err != nil {
+			return nil, err
+		}
+
+		options := VariableCreateOptions{
+			Key:       String(key),
+			Value:     String(value),
+			Category:  Category(CategoryType(category)),
+			HCL:       Bool(hcl),
+			Sensitive: Bool(sensitive),
+		}
+		if description != "" {
+			options.Description = String(description)
+		}
+
+		// Make sure the options can be used to create the variable once
+		// a workspace is set.
+		check := options
+		check.Workspace = &Workspace{}
+		if err := check.valid(); err != nil {
+			return nil, &VariableFileError{Field: field, Message: err.Error()}
+		}
+
+		result = append(result, options)
+	}
+
+	return result, nil
+}
+
+// fileObject returns v as an object.
+func fileObject(field string, v interface{}) (map[string]interface{}, error) {
+	if obj, ok := v.(map[string]interface{}); ok {
+		return obj, nil
+	}
+	return nil, &VariableFileError{Field: field, Message: "must be an object"}
+}
+
+// yamlScalar is a YAML scalar that is not a string, like a number or a
+// bool. The source text is kept, as YAML resolves scalars like 1.10, 0777
+// or 1e3 to values that do not round trip to the same text.
+type yamlScalar struct {
+	text  string
+	value interface{}
+}
+
+// yamlValue converts a decoded YAML node into the same kind of values the
+// JSON decoder returns, except for non-string scalars which are returned as
+// a yamlScalar.
+func yamlValue(node *yaml.Node) interface{} {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return yamlValue(node.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(node.Alias)
+	case yaml.MappingNode:
+		obj := make(map[string]interface{}, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			obj[node.Content[i].Value] = yamlValue(node.Content[i+1])
+		}
+		return obj
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(node.Content))
+		for _, item := range node.Content {
+			list = append(list, yamlValue(item))
+		}
+		return list
+	case yaml.ScalarNode:
+	default:
+		return nil
+	}
+
+	switch node.ShortTag() {
+	case "!!null":
+		return nil
+	case "!!str":
+		return node.Value
+	}
+
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		value = node.Value
+	}
+	return yamlScalar{text: node.Value, value: value}
+}
+
+// checkFileFields returns an error if obj contains any field that is not
+// one of the given known fields.
+func checkFileFields(field string, obj map[string]interface{}, known ...string) error {
+	var unknown []string
+	for name := range obj {
+		found := false
+		for _, k := range known {
+			if name == k {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+	if field != "" {
+		field += "."
+	}
+	return &VariableFileError{Field: field + unknown[0], Message: "unknown field"}
+}
+
+// fileString returns v as a string. Numbers and bools are accepted as well
+// and returned as written in the file.
+func fileString(field string, v interface{}, required bool) (string, error) {
+	var s string
+	switch v := v.(type) {
+	case nil:
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case bool:
+		s = strconv.FormatBool(v)
+	case yamlScalar:
+		s = v.text
+	default:
+		return "", &VariableFileError{Field: field, Message: "must be a string"}
+	}
+	if required && s == "" {
+		return "", &VariableFileError{Field: field, Message: "is required"}
+	}
+	return s, nil
+}
+
+// fileBool returns v as a bool, where a missing value is false.
+func fileBool(field string, v interface{}) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case yamlScalar:
+		if b, ok := v.value.(bool); ok {
+			return b, nil
+		}
+	}
+	return false, &VariableFileError{Field: field, Message: "must be a bool"}
+}
@@ -0,0 +1,387 @@
+package tfe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTfvars(t *testing.T) {
+	t.Run("with valid assignments", func(t *testing.T) {
+		data := []byte(`# A comment.
+name    = "say \"hi\"" # Trailing comment.
+escaped = "$${literal}"
+count   = 3
+enabled = true
+list    = ["a", "b"]
+map = {
+  foo = "bar" // Inline comment.
+  baz = ["}"]
+}
+script = <<-EOT
+  echo hello
+    indented
+  EOT
+`)
+
+		options, err := parseTfvars(data)
+		require.NoError(t, err)
+		require.Len(t, options, 7)
+
+		expected := []struct {
+			key   string
+			value string
+			hcl   bool
+		}{
+			{"name", `say "hi"`, false},
+			{"escaped", "${literal}", false},
+			{"count", "3", false},
+			{"enabled", "true", false},
+			{"list", `["a", "b"]`, true},
+			{"map", "{\n  foo = \"bar\" \n  baz = [\"}\"]\n}", true},
+			{"script", "echo hello\n  indented\n", false},
+		}
+
+		for i, e := range expected {
+			assert.Equal(t, e.key, *options[i].Key)
+			assert.Equal(t, e.value, *options[i].Value)
+			assert.Equal(t, e.hcl, *options[i].HCL)
+			assert.Equal(t, CategoryTerraform, *options[i].Category)
+		}
+	})
+
+	t.Run("round trips exported values", func(t *testing.T) {
+		for _, value := range []string{"line1\n\"quoted\" \\ ${var.foo}", ""} {
+			options, err := parseTfvars([]byte("name = " + quoteHCLString(value)))
+			require.NoError(t, err)
+			require.Len(t, options, 1)
+			assert.Equal(t, value, *options[0].Value)
+		}
+	})
+
+	cases := map[string]struct {
+		data string
+		err  string
+	}{
+		"missing assignment": {
+			data: "\nfoo\n",
+			err:  "line 2: expected an assignment",
+		},
+		"invalid name": {
+			data: "foo bar = 1",
+			err:  `line 1: invalid variable name "foo bar"`,
+		},
+		"missing value": {
+			data: "foo =",
+			err:  `line 1: missing value for "foo"`,
+		},
+		"comment instead of value": {
+			data: "foo = # comment",
+			err:  `line 1: missing value for "foo"`,
+		},
+		"unterminated string": {
+			data: `foo = "bar`,
+			err:  "line 1: unterminated string",
+		},
+		"unterminated list": {
+			data: "a = 1\nfoo = [\n  1,\n",
+			err:  "line 2: unterminated value",
+		},
+		"unterminated heredoc": {
+			data: "foo = <<EOT\nbar\n",
+			err:  "line 1: unterminated heredoc",
+		},
+		"trailing garbage": {
+			data: `foo = "bar" baz`,
+			err:  `line 1: unexpected "baz" after value`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			options, err := parseTfvars([]byte(tc.data))
+			assert.Nil(t, options)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestParseDotenv(t *testing.T) {
+	t.Run("with valid assignments", func(t *testing.T) {
+		data := []byte(`# A comment.
+PLAIN=value # Trailing comment.
+export EXPORTED=yes
+DOUBLE="line1\nline2 \"quoted\""
+SINGLE='raw \n value'
+`)
+
+		options, err := parseDotenv(data)
+		require.NoError(t, err)
+		require.Len(t, options, 4)
+
+		expected := []struct {
+			key   string
+			value string
+		}{
+			{"PLAIN", "value"},
+			{"EXPORTED", "yes"},
+			{"DOUBLE", "line1\nline2 \"quoted\""},
+			{"SINGLE", `raw \n value`},
+		}
+
+		for i, e := range expected {
+			assert.Equal(t, e.key, *options[i].Key)
+			assert.Equal(t, e.value, *options[i].Value)
+			assert.Equal(t, CategoryEnv, *options[i].Category)
+		}
+	})
+
+	t.Run("round trips exported values", func(t *testing.T) {
+		for _, value := range []string{"line1\n\"quoted\" \\ value", ""} {
+			options, err := parseDotenv([]byte("NAME=" + quoteDotenvString(value)))
+			require.NoError(t, err)
+			require.Len(t, options, 1)
+			assert.Equal(t, value, *options[0].Value)
+		}
+	})
+
+	t.Run("with empty quoted values", func(t *testing.T) {
+		options, err := parseDotenv([]byte("DOUBLE=\"\"\nSINGLE='' # Empty.\n"))
+		require.NoError(t, err)
+		require.Len(t, options, 2)
+		assert.Equal(t, "", *options[0].Value)
+		assert.Equal(t, "", *options[1].Value)
+	})
+
+	cases := map[string]struct {
+		data string
+		err  string
+	}{
+		"missing assignment": {
+			data: "FOO",
+			err:  "line 1: expected KEY=VALUE",
+		},
+		"invalid name": {
+			data: "\nFOO-BAR=1",
+			err:  `line 2: invalid variable name "FOO-BAR"`,
+		},
+		"missing value": {
+			data: "FOO=",
+			err:  `line 1: missing value for "FOO"`,
+		},
+		"comment instead of value": {
+			data: "FOO= # Comment.",
+			err:  `line 1: missing value for "FOO"`,
+		},
+		"unterminated string": {
+			data: `FOO="bar`,
+			err:  "line 1: unterminated string",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			options, err := parseDotenv([]byte(tc.data))
+			assert.Nil(t, options)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestCheckHCLSyntax(t *testing.T) {
+	t.Run("with valid expressions", func(t *testing.T) {
+		for _, expr := range []string{
+			`["a", "b"]`,
+			`{ foo = "bar", baz = [1, 2] }`,
+			`"${var.name}-${lookup(var.map, "key")}"`,
+			`"escaped $${literal} and \" quote"`,
+			"{\n  # A comment with a ] bracket.\n  foo = \"bar\" // Another }\n  /* block\n comment ( */\n}",
+			"<<EOT\nunbalanced ] [ \"\nEOT",
+			`length(var.list) > 0 ? "yes" : "no"`,
+		} {
+			assert.Nil(t, checkHCLSyntax(expr), expr)
+		}
+	})
+
+	t.Run("with invalid expressions", func(t *testing.T) {
+		tests := []struct {
+			expr    string
+			line    int
+			column  int
+			message string
+		}{
+			{"", 1, 1, "empty expression"},
+			{`["a", "b"`, 1, 1, "Unterminated tuple constructor expression"},
+			{"{\n  foo = [1, 2}\n}", 2, 14, "Missing item separator"},
+			{"{\n  foo = \"bar\n}", 2, 13, "Invalid multi-line string"},
+			{`"${var.name`, 1, 2, "Unclosed template interpolation sequence"},
+			{"<<EOT\nfoo\n", 3, 1, "Unterminated template string"},
+			{"1 + 2)", 1, 6, "Extra characters after expression"},
+			{"[1,,2]", 1, 4, "Invalid expression"},
+			{"{foo bar}", 1, 6, "Missing key/value separator"},
+			{`"a" "b"`, 1, 5, "Extra characters after expression"},
+		}
+
+		for _, tt := range tests {
+			err := checkHCLSyntax(tt.expr)
+			require.NotNil(t, err, tt.expr)
+			assert.Equal(t, tt.line, err.Line, tt.expr)
+			assert.Equal(t, tt.column, err.Column, tt.expr)
+			assert.Equal(t, tt.message, err.Message, tt.expr)
+		}
+	})
+}
+
+func TestParseVariableFile(t *testing.T) {
+	t.Run("with a valid YAML file", func(t *testing.T) {
+		data := []byte(`variables:
+  - key: region
+    value: eu-west-1
+    category: terraform
+    description: The region to deploy to.
+  - key: count
+    value: 3
+    category: terraform
+  - key: tags
+    value: '{ env = "prod" }'
+    category: terraform
+    hcl: true
+  - key: SECRET
+    value: s3cr3t
+    category: env
+    sensitive: true
+`)
+
+		options, err := ParseVariableFile(data, "yaml")
+		require.NoError(t, err)
+		require.Len(t, options, 4)
+
+		expected := []struct {
+			key       string
+			value     string
+			category  CategoryType
+			hcl       bool
+			sensitive bool
+		}{
+			{"region", "eu-west-1", CategoryTerraform, false, false},
+			{"count", "3", CategoryTerraform, false, false},
+			{"tags", `{ env = "prod" }`, CategoryTerraform, true, false},
+			{"SECRET", "s3cr3t", CategoryEnv, false, true},
+		}
+
+		for i, e := range expected {
+			assert.Equal(t, e.key, *options[i].Key)
+			assert.Equal(t, e.value, *options[i].Value)
+			assert.Equal(t, e.category, *options[i].Category)
+			assert.Equal(t, e.hcl, *options[i].HCL)
+			assert.Equal(t, e.sensitive, *options[i].Sensitive)
+			assert.Nil(t, options[i].Workspace)
+		}
+
+		require.NotNil(t, options[0].Description)
+		assert.Equal(t, "The region to deploy to.", *options[0].Description)
+		assert.Nil(t, options[1].Description)
+	})
+
+	t.Run("with a valid JSON file", func(t *testing.T) {
+		data := []byte(`{"variables": [
+  {"key": "region", "value": "eu-west-1", "category": "terraform"},
+  {"key": "size", "value": 1.50, "category": "terraform"}
+]}`)
+
+		options, err := ParseVariableFile(data, "json")
+		require.NoError(t, err)
+		require.Len(t, options, 2)
+		assert.Equal(t, "region", *options[0].Key)
+		assert.Equal(t, "eu-west-1", *options[0].Value)
+		assert.Equal(t, "1.50", *options[1].Value)
+	})
+
+	t.Run("with invalid files", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			format string
+			data   string
+			err    string
+		}{
+			{"unsupported format", "toml", ``, `unsupported variable file format "toml"`},
+			{"invalid JSON", "json", `{`, "invalid JSON: unexpected EOF"},
+			{"not an object", "yaml", `- foo`, "must be an object"},
+			{"missing variables", "yaml", `foo: bar`, "foo: unknown field"},
+			{"empty variables", "json", `{"variables": null}`, "variables: is required"},
+			{"variables not a list", "yaml", `variables: foo`, "variables: must be a list"},
+			{"variable not an object", "yaml", "variables:\n  - foo", "variables[0]: must be an object"},
+			{"unknown field", "yaml", "variables:\n  - key: a\n    valu: b\n    category: terraform", "variables[0].valu: unknown field"},
+			{"missing category", "yaml", "variables:\n  - key: a\n    value: b", "variables[0].category: is required"},
+			{"invalid category", "yaml", "variables:\n  - key: a\n    value: b\n    category: secret", `variables[0].category: must be "terraform" or "env"`},
+			{"missing key", "yaml", "variables:\n  - value: b\n    category: env", "variables[0].key: is required"},
+			{"invalid key", "yaml", "variables:\n  - key: my-var\n    value: b\n    category: env", `variables[0].key: invalid variable name "my-var"`},
+			{"value not a string", "yaml", "variables:\n  - key: a\n    value: [b]\n    category: env", "variables[0].value: must be a string"},
+			{"hcl not a bool", "yaml", "variables:\n  - key: a\n    value: b\n    category: env\n    hcl: yes please", "variables[0].hcl: must be a bool"},
+			{"invalid HCL", "yaml", "variables:\n  - key: a\n    value: '[1, 2'\n    category: terraform\n    hcl: true", "variables[0].value: invalid HCL: 1:1: Unterminated tuple constructor expression"},
+			{"duplicate key", "yaml", "variables:\n  - key: a\n    value: b\n    category: env\n  - key: a\n    value: c\n    category: env", `variables[1].key: duplicate env variable "a"`},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				options, err := ParseVariableFile([]byte(tc.data), tc.format)
+				assert.Nil(t, options)
+				assert.EqualError(t, err, tc.err)
+			})
+		}
+	})
+
+	t.Run("with numbers and bools as values", func(t *testing.T) {
+		data := []byte(`variables:
+  - key: version
+    value: 1.10
+    category: terraform
+  - key: mode
+    value: 0777
+    category: terraform
+  - key: enabled
+    value: yes
+    category: terraform
+  - key: STRICT
+    value: NO
+    category: env
+  - key: flag
+    value: True
+    category: terraform
+  - key: size
+    value: 1e3
+    category: terraform
+    hcl: TRUE
+`)
+
+		options, err := ParseVariableFile(data, "yaml")
+		require.NoError(t, err)
+		require.Len(t, options, 6)
+
+		expected := []string{"1.10", "0777", "yes", "NO", "True", "1e3"}
+		for i, e := range expected {
+			assert.Equal(t, e, *options[i].Value)
+		}
+		assert.True(t, *options[5].HCL)
+	})
+
+	t.Run("with empty values", func(t *testing.T) {
+		for _, data := range []string{
+			"variables:\n  - key: a\n    value: b\n    category: env\n  - key: c\n    value: \"\"\n    category: env",
+			"variables:\n  - key: a\n    value: b\n    category: env\n  - key: c\n    category: terraform",
+		} {
+			options, err := ParseVariableFile([]byte(data), "yaml")
+			assert.Nil(t, options)
+			assert.EqualError(t, err, "variables[1]: value is required")
+		}
+	})
+
+	t.Run("with the same key in both categories", func(t *testing.T) {
+		data := []byte("variables:\n  - key: a\n    value: b\n    category: env\n  - key: a\n    value: c\n    category: terraform")
+
+		options, err := ParseVariableFile(data, "yml")
+		require.NoError(t, err)
+		assert.Len(t, options, 2)
+	})
+}
@@ -0,0 +1,364 @@
+package tfe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// Compile-time proof of interface implementation.
+var _ VariableSets = (*variableSets)(nil)
+
+// VariableSets describes all the variable set related methods that the
+// Terraform Enterprise API supports.
+//
+// TFE API docs: https://www.terraform.io/docs/cloud/api/variable-sets.html
+type VariableSets interface {
+	// List all the variable sets of the given organization.
+	List(ctx context.Context, organization string, options VariableSetListOptions) (*VariableSetList, error)
+
+	// ListForWorkspace lists all the variable sets applied to a workspace.
+	ListForWorkspace(ctx context.Context, workspaceID string, options VariableSetListOptions) (*VariableSetList, error)
+
+	// Create a new variable set with the given options.
+	Create(ctx context.Context, organization string, options VariableSetCreateOptions) (*VariableSet, error)
+
+	// Read a variable set by its ID.
+	Read(ctx context.Context, variableSetID string, options VariableSetReadOptions) (*VariableSet, error)
+
+	// Update an existing variable set.
+	Update(ctx context.Context, variableSetID string, options VariableSetUpdateOptions) (*VariableSet, error)
+
+	// Delete a variable set by its ID.
+	Delete(ctx context.Context, variableSetID string) error
+
+	// ApplyToWorkspaces applies a variable set to workspaces.
+	ApplyToWorkspaces(ctx context.Context, variableSetID string, options VariableSetApplyToWorkspacesOptions) error
+
+	// RemoveFromWorkspaces removes a variable set from workspaces.
+	RemoveFromWorkspaces(ctx context.Context, variableSetID string, options VariableSetRemoveFromWorkspacesOptions) error
+}
+
+// variableSets implements VariableSets.
+type variableSets struct {
+	client *Client
+}
+
+// VariableSetList represents a list of variable sets.
+type VariableSetList struct {
+	*Pagination
+	Items []*VariableSet
+}
+
+// VariableSet represents a Terraform Enterprise variable set.
+type VariableSet struct {
+	ID          string `jsonapi:"primary,varsets"`
+	Name        string `jsonapi:"attr,name"`
+	Description string `jsonapi:"attr,description"`
+	Global      bool   `jsonapi:"attr,global"`
+	Priority    bool   `jsonapi:"attr,priority"`
+
+	// Relations
+	Organization *Organization `jsonapi:"relation,organization"`
+	Variables    []*Variable   `jsonapi:"relation,vars"`
+	Workspaces   []*Workspace  `jsonapi:"relation,workspaces"`
+}
+
+// VariableSetListOptions represents the options for listing variable sets.
+type VariableSetListOptions struct {
+	ListOptions
+
+	// A comma-separated list of related resources to include, for example
+	// "vars" to include the variables of each set.
+	Include *string `url:"include,omitempty"`
+
+	// Only return the variable sets that are (or are not) applied globally.
+	// The API does not support this filter, so the sets are filtered after
+	// each page is retrieved. A filtered page can therefore contain fewer
+	// items than the page size, while the pagination details still describe
+	// the unfiltered list.
+	Global *bool `url:"-"`
+}
+
+// List all the variable sets of the given organization.
+func (s *variableSets) List(ctx context.Context, organization string, options VariableSetListOptions) (*VariableSetList, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+
+	u := fmt.Sprintf("organizations/%s/varsets", url.PathEscape(organization))
+	return s.list(ctx, u, options)
+}
+
+// ListForWorkspace lists all the variable sets applied to the given
+// workspace, including the global variable sets.
+func (s *variableSets) ListForWorkspace(ctx context.Context, workspaceID string, options VariableSetListOptions) (*VariableSetList, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s/varsets", url.PathEscape(workspaceID))
+	return s.list(ctx, u, options)
+}
+
+func (s *variableSets) list(ctx context.Context, u string, options VariableSetListOptions) (*VariableSetList, error) {
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	vsl := &VariableSetList{}
+	err = s.client.do(ctx, req, vsl)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	if options.Global != nil {
+		var items []*VariableSet
+		for _, vs := range vsl.Items {
+			if vs.Global == *options.Global {
+				items = append(items, vs)
+			}
+		}
+		vsl.Items = items
+	}
+
+	return vsl, err
+}
+
+// VariableSetCreateOptions represents the options for creating a new
+// variable set.
+type VariableSetCreateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,varsets"`
+
+	// The name of the variable set.
+	Name *string `jsonapi:"attr,name"`
+
+	// The description of the variable set.
+	Description *string `jsonapi:"attr,description,omitempty"`
+
+	// Whether the variable set applies to all workspaces in the organization.
+	Global *bool `jsonapi:"attr,global,omitempty"`
+
+	// Whether the variables in the set override any other variables with the
+	// same key, including the variables of the workspace itself.
+	Priority *bool `jsonapi:"attr,priority,omitempty"`
+
+	// The workspaces the variable set is applied to. A global variable set
+	// applies to all workspaces, so it cannot list specific workspaces.
+	Workspaces []*Workspace `jsonapi:"relation,workspaces,omitempty"`
+}
+
+func (o VariableSetCreateOptions) valid() error {
+	if !validString(o.Name) {
+		return errors.New("name is required")
+	}
+	if o.Global != nil && *o.Global && len(o.Workspaces) > 0 {
+		return errors.New("global variable sets cannot be applied to specific workspaces")
+	}
+	return nil
+}
+
+// Create a new variable set with the given options.
+func (s *variableSets) Create(ctx context.Context, organization string, options VariableSetCreateOptions) (*VariableSet, error) {
+	if !validStringID(&organization) {
+		return nil, errors.New("invalid value for organization")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("organizations/%s/varsets", url.PathEscape(organization))
+	req, err := s.client.newRequest("POST", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := &VariableSet{}
+	err = s.client.do(ctx, req, vs)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+// VariableSetReadOptions represents the options for reading a variable set.
+type VariableSetReadOptions struct {
+	// A comma-separated list of related resources to include, for example
+	// "vars" to include the variables of the set.
+	Include *string `url:"include,omitempty"`
+}
+
+// Read a variable set by its ID.
+func (s *variableSets) Read(ctx context.Context, variableSetID string, options VariableSetReadOptions) (*VariableSet, error) {
+	if !validStringID(&variableSetID) {
+		return nil, errors.New("invalid value for variable set ID")
+	}
+
+	u := fmt.Sprintf("varsets/%s", url.PathEscape(variableSetID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := &VariableSet{}
+	err = s.client.do(ctx, req, vs)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+// VariableSetUpdateOptions represents the options for updating a variable
+// set.
+type VariableSetUpdateOptions struct {
+	// For internal use only!
+	ID string `jsonapi:"primary,varsets"`
+
+	// The name of the variable set.
+	Name *string `jsonapi:"attr,name,omitempty"`
+
+	// The description of the variable set.
+	Description *string `jsonapi:"attr,description,omitempty"`
+
+	// Whether the variable set applies to all workspaces in the organization.
+	Global *bool `jsonapi:"attr,global,omitempty"`
+
+	// Whether the variables in the set override any other variables with the
+	// same key, including the variables of the workspace itself.
+	Priority *bool `jsonapi:"attr,priority,omitempty"`
+}
+
+// Update an existing variable set.
+func (s *variableSets) Update(ctx context.Context, variableSetID string, options VariableSetUpdateOptions) (*VariableSet, error) {
+	if !validStringID(&variableSetID) {
+		return nil, errors.New("invalid value for variable set ID")
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("varsets/%s", url.PathEscape(variableSetID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	vs := &VariableSet{}
+	err = s.client.do(ctx, req, vs)
+	if err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+// Delete a variable set by its ID.
+func (s *variableSets) Delete(ctx context.Context, variableSetID string) error {
+	if !validStringID(&variableSetID) {
+		return errors.New("invalid value for variable set ID")
+	}
+
+	u := fmt.Sprintf("varsets/%s", url.PathEscape(variableSetID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// VariableSetApplyToWorkspacesOptions represents the options for applying a
+// variable set to workspaces.
+type VariableSetApplyToWorkspacesOptions struct {
+	// The workspaces to apply the variable set to.
+	Workspaces []*Workspace
+}
+
+func (o VariableSetApplyToWorkspacesOptions) valid() error {
+	if o.Workspaces == nil {
+		return errors.New("workspaces is required")
+	}
+	if len(o.Workspaces) == 0 {
+		return errors.New("must provide at least one workspace")
+	}
+	return nil
+}
+
+// ApplyToWorkspaces applies a variable set to the given workspaces.
+func (s *variableSets) ApplyToWorkspaces(ctx context.Context, variableSetID string, options VariableSetApplyToWorkspacesOptions) error {
+	if !validStringID(&variableSetID) {
+		return errors.New("invalid value for variable set ID")
+	}
+	if err := options.valid(); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("varsets/%s/relationships/workspaces", url.PathEscape(variableSetID))
+	req, err := s.client.newRequest("POST", u, options.Workspaces)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// VariableSetRemoveFromWorkspacesOptions represents the options for removing
+// a variable set from workspaces.
+type VariableSetRemoveFromWorkspacesOptions struct {
+	// The workspaces to remove the variable set from.
+	Workspaces []*Workspace
+}
+
+func (o VariableSetRemoveFromWorkspacesOptions) valid() error {
+	if o.Workspaces == nil {
+		return errors.New("workspaces is required")
+	}
+	if len(o.Workspaces) == 0 {
+		return errors.New("must provide at least one workspace")
+	}
+	return nil
+}
+
+// RemoveFromWorkspaces removes a variable set from the given workspaces.
+func (s *variableSets) RemoveFromWorkspaces(ctx context.Context, variableSetID string, options VariableSetRemoveFromWorkspacesOptions) error {
+	if !validStringID(&variableSetID) {
+		return errors.New("invalid value for variable set ID")
+	}
+	if err := options.valid(); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("varsets/%s/relationships/workspaces", url.PathEscape(variableSetID))
+	req, err := s.client.newRequest("DELETE", u, options.Workspaces)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// SortVariableSetsByPrecedence sorts the given variable sets from the highest
+// to the lowest precedence. Priority sets take precedence over all other
+// sets, and sets applied to specific workspaces take precedence over global
+// sets. Sets with the same precedence are sorted by name, where the first
+// name takes precedence.
+func SortVariableSetsByPrecedence(sets []*VariableSet) {
+	sort.SliceStable(sets, func(i, j int) bool {
+		if sets[i].Priority != sets[j].Priority {
+			return sets[i].Priority
+		}
+		if sets[i].Global != sets[j].Global {
+			return !sets[i].Global
+		}
+		return sets[i].Name < sets[j].Name
+	})
+}
@@ -0,0 +1,260 @@
+package tfe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableSetsList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	vsTest1, _ := createVariableSet(t, client, orgTest, VariableSetCreateOptions{})
+	vsTest2, _ := createVariableSet(t, client, orgTest, VariableSetCreateOptions{})
+
+	t.Run("without list options", func(t *testing.T) {
+		vsl, err := client.VariableSets.List(ctx, orgTest.Name, VariableSetListOptions{})
+		require.NoError(t, err)
+
+		var ids []string
+		for _, vs := range vsl.Items {
+			ids = append(ids, vs.ID)
+		}
+		assert.Contains(t, ids, vsTest1.ID)
+		assert.Contains(t, ids, vsTest2.ID)
+	})
+
+	t.Run("with only global variable sets", func(t *testing.T) {
+		vsGlobal, _ := createVariableSet(t, client, orgTest, VariableSetCreateOptions{
+			Global: Bool(true),
+		})
+
+		vsl, err := client.VariableSets.List(ctx, orgTest.Name, VariableSetListOptions{
+			Global: Bool(true),
+		})
+		require.NoError(t, err)
+		require.Len(t, vsl.Items, 1)
+		assert.Equal(t, vsGlobal.ID, vsl.Items[0].ID)
+		assert.True(t, vsl.Items[0].Global)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		vsl, err := client.VariableSets.List(ctx, badIdentifier, VariableSetListOptions{})
+		assert.Nil(t, vsl)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestVariableSetsCreate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	t.Run("with valid options", func(t *testing.T) {
+		options := VariableSetCreateOptions{
+			Name:        String(randomString(t)),
+			Description: String("a variable set"),
+			Global:      Bool(false),
+			Priority:    Bool(true),
+			Workspaces:  []*Workspace{wTest},
+		}
+
+		vs, err := client.VariableSets.Create(ctx, orgTest.Name, options)
+		require.NoError(t, err)
+
+		assert.Equal(t, *options.Name, vs.Name)
+		assert.Equal(t, *options.Description, vs.Description)
+		assert.False(t, vs.Global)
+		assert.True(t, vs.Priority)
+		require.Len(t, vs.Workspaces, 1)
+		assert.Equal(t, wTest.ID, vs.Workspaces[0].ID)
+	})
+
+	t.Run("when a global set lists workspaces", func(t *testing.T) {
+		vs, err := client.VariableSets.Create(ctx, orgTest.Name, VariableSetCreateOptions{
+			Name:       String(randomString(t)),
+			Global:     Bool(true),
+			Workspaces: []*Workspace{wTest},
+		})
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "global variable sets cannot be applied to specific workspaces")
+	})
+
+	t.Run("when options is missing name", func(t *testing.T) {
+		vs, err := client.VariableSets.Create(ctx, orgTest.Name, VariableSetCreateOptions{})
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "name is required")
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		vs, err := client.VariableSets.Create(ctx, badIdentifier, VariableSetCreateOptions{
+			Name: String(randomString(t)),
+		})
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestVariableSetsRead(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	vsTest, vsTestCleanup := createVariableSet(t, client, nil, VariableSetCreateOptions{})
+	defer vsTestCleanup()
+
+	t.Run("when the variable set exists", func(t *testing.T) {
+		vs, err := client.VariableSets.Read(ctx, vsTest.ID, VariableSetReadOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, vsTest.ID, vs.ID)
+		assert.Equal(t, vsTest.Name, vs.Name)
+	})
+
+	t.Run("when the variable set does not exist", func(t *testing.T) {
+		vs, err := client.VariableSets.Read(ctx, "nonexisting", VariableSetReadOptions{})
+		assert.Nil(t, vs)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid variable set ID", func(t *testing.T) {
+		vs, err := client.VariableSets.Read(ctx, badIdentifier, VariableSetReadOptions{})
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "invalid value for variable set ID")
+	})
+}
+
+func TestVariableSetsUpdate(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	vsTest, vsTestCleanup := createVariableSet(t, client, nil, VariableSetCreateOptions{})
+	defer vsTestCleanup()
+
+	t.Run("with valid options", func(t *testing.T) {
+		options := VariableSetUpdateOptions{
+			Name:     String(randomString(t)),
+			Global:   Bool(true),
+			Priority: Bool(true),
+		}
+
+		vs, err := client.VariableSets.Update(ctx, vsTest.ID, options)
+		require.NoError(t, err)
+
+		assert.Equal(t, *options.Name, vs.Name)
+		assert.True(t, vs.Global)
+		assert.True(t, vs.Priority)
+	})
+
+	t.Run("without a valid variable set ID", func(t *testing.T) {
+		vs, err := client.VariableSets.Update(ctx, badIdentifier, VariableSetUpdateOptions{})
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "invalid value for variable set ID")
+	})
+}
+
+func TestVariableSetsDelete(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	vsTest, _ := createVariableSet(t, client, orgTest, VariableSetCreateOptions{})
+
+	t.Run("with a valid ID", func(t *testing.T) {
+		err := client.VariableSets.Delete(ctx, vsTest.ID)
+		require.NoError(t, err)
+
+		_, err = client.VariableSets.Read(ctx, vsTest.ID, VariableSetReadOptions{})
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without a valid variable set ID", func(t *testing.T) {
+		err := client.VariableSets.Delete(ctx, badIdentifier)
+		assert.EqualError(t, err, "invalid value for variable set ID")
+	})
+}
+
+func TestVariableSetsApplyToWorkspaces(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+	vsTest, _ := createVariableSet(t, client, orgTest, VariableSetCreateOptions{})
+
+	t.Run("with valid options", func(t *testing.T) {
+		err := client.VariableSets.ApplyToWorkspaces(ctx, vsTest.ID, VariableSetApplyToWorkspacesOptions{
+			Workspaces: []*Workspace{wTest},
+		})
+		require.NoError(t, err)
+
+		vsl, err := client.VariableSets.ListForWorkspace(ctx, wTest.ID, VariableSetListOptions{})
+		require.NoError(t, err)
+		require.Len(t, vsl.Items, 1)
+		assert.Equal(t, vsTest.ID, vsl.Items[0].ID)
+	})
+
+	t.Run("when removing the variable set again", func(t *testing.T) {
+		err := client.VariableSets.RemoveFromWorkspaces(ctx, vsTest.ID, VariableSetRemoveFromWorkspacesOptions{
+			Workspaces: []*Workspace{wTest},
+		})
+		require.NoError(t, err)
+
+		vsl, err := client.VariableSets.ListForWorkspace(ctx, wTest.ID, VariableSetListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, vsl.Items)
+	})
+
+	t.Run("without any workspaces", func(t *testing.T) {
+		err := client.VariableSets.ApplyToWorkspaces(ctx, vsTest.ID, VariableSetApplyToWorkspacesOptions{
+			Workspaces: []*Workspace{},
+		})
+		assert.EqualError(t, err, "must provide at least one workspace")
+	})
+
+	t.Run("without a valid variable set ID", func(t *testing.T) {
+		err := client.VariableSets.ApplyToWorkspaces(ctx, badIdentifier, VariableSetApplyToWorkspacesOptions{
+			Workspaces: []*Workspace{wTest},
+		})
+		assert.EqualError(t, err, "invalid value for variable set ID")
+	})
+}
+
+func TestSortVariableSetsByPrecedence(t *testing.T) {
+	sets := []*VariableSet{
+		{Name: "global-b", Global: true},
+		{Name: "scoped-b"},
+		{Name: "priority-global", Global: true, Priority: true},
+		{Name: "global-a", Global: true},
+		{Name: "priority-scoped", Priority: true},
+		{Name: "scoped-a"},
+	}
+
+	SortVariableSetsByPrecedence(sets)
+
+	var names []string
+	for _, vs := range sets {
+		names = append(names, vs.Name)
+	}
+
+	assert.Equal(t, []string{
+		"priority-scoped",
+		"priority-global",
+		"scoped-a",
+		"scoped-b",
+		"global-a",
+		"global-b",
+	}, names)
+}
@@ -2,7 +2,11 @@ package tfe
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -70,6 +74,38 @@ func TestVariablesList(t *testing.T) {
 	})
 }
 
+func TestVariablesListMap(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	vTest1, _ := createVariable(t, client, wTest)
+	vTest2, _ := createVariable(t, client, wTest)
+
+	t.Run("with valid options", func(t *testing.T) {
+		vm, err := client.Variables.ListMap(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+		assert.Len(t, vm, 2)
+		assert.Equal(t, vTest1, vm[vTest1.ID])
+		assert.Equal(t, vTest2, vm[vTest2.ID])
+	})
+
+	t.Run("when options is missing an organization", func(t *testing.T) {
+		vm, err := client.Variables.ListMap(ctx, VariableListOptions{
+			Workspace: String(wTest.Name),
+		})
+		assert.Nil(t, vm)
+		assert.EqualError(t, err, "organization is required")
+	})
+}
+
 func TestVariablesCreate(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -259,3 +295,596 @@ func TestVariablesDelete(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for variable ID")
 	})
 }
+
+func TestVariablesExportTfvars(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	for _, options := range []VariableCreateOptions{
+		{Key: String("name"), Value: String(`say "hi"`), Category: Category(CategoryTerraform)},
+		{Key: String("list"), Value: String(`["a", "b"]`), Category: Category(CategoryTerraform), HCL: Bool(true)},
+		{Key: String("secret"), Value: String("hidden"), Category: Category(CategoryTerraform), Sensitive: Bool(true)},
+		{Key: String("ENV_VAR"), Value: String("env"), Category: Category(CategoryEnv)},
+	} {
+		options.Workspace = wTest
+		_, err := client.Variables.Create(ctx, options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with valid options", func(t *testing.T) {
+		data, err := client.Variables.ExportTfvars(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "list = [\"a\", \"b\"]\n"+
+			"name = \"say \\\"hi\\\"\"\n"+
+			"# secret omitted: sensitive value\n", string(data))
+	})
+
+	t.Run("when options is missing an organization", func(t *testing.T) {
+		data, err := client.Variables.ExportTfvars(ctx, VariableListOptions{
+			Workspace: String(wTest.Name),
+		})
+		assert.Nil(t, data)
+		assert.EqualError(t, err, "organization is required")
+	})
+}
+
+func TestVariablesExportDotenv(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	for _, options := range []VariableCreateOptions{
+		{Key: String("name"), Value: String("terraform"), Category: Category(CategoryTerraform)},
+		{Key: String("MESSAGE"), Value: String("line1\nline2"), Category: Category(CategoryEnv)},
+		{Key: String("TOKEN"), Value: String("hidden"), Category: Category(CategoryEnv), Sensitive: Bool(true)},
+	} {
+		options.Workspace = wTest
+		_, err := client.Variables.Create(ctx, options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with valid options", func(t *testing.T) {
+		data, err := client.Variables.ExportDotenv(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "MESSAGE=\"line1\\nline2\"\n"+
+			"# TOKEN omitted: sensitive value\n", string(data))
+	})
+
+	t.Run("when options is missing a workspace", func(t *testing.T) {
+		data, err := client.Variables.ExportDotenv(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+		})
+		assert.Nil(t, data)
+		assert.EqualError(t, err, "workspace is required")
+	})
+}
+
+func TestVariablesImportTfvars(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	t.Run("with a valid file", func(t *testing.T) {
+		vs, err := client.Variables.ImportTfvars(ctx, wTest.ID, []byte(
+			"name = \"foo\"\nlist = [\"a\", \"b\"]\n",
+		))
+		require.NoError(t, err)
+		require.Len(t, vs, 2)
+
+		assert.Equal(t, "name", vs[0].Key)
+		assert.Equal(t, "foo", vs[0].Value)
+		assert.Equal(t, CategoryTerraform, vs[0].Category)
+		assert.False(t, vs[0].HCL)
+
+		assert.Equal(t, "list", vs[1].Key)
+		assert.Equal(t, `["a", "b"]`, vs[1].Value)
+		assert.True(t, vs[1].HCL)
+	})
+
+	t.Run("with an invalid file", func(t *testing.T) {
+		vs, err := client.Variables.ImportTfvars(ctx, wTest.ID, []byte("foo"))
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "line 1: expected an assignment")
+	})
+
+	t.Run("with an empty value", func(t *testing.T) {
+		vs, err := client.Variables.ImportTfvars(ctx, wTest.ID, []byte("first = 1\nempty = \"\"\n"))
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, `invalid variable "empty": value is required`)
+
+		// No variable should have been created.
+		vl, err := client.Variables.List(ctx, VariableListOptions{
+			Organization: String(wTest.Organization.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+		for _, v := range vl.Items {
+			assert.NotEqual(t, "first", v.Key)
+		}
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		vs, err := client.Variables.ImportTfvars(ctx, badIdentifier, []byte(`name = "foo"`))
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestVariablesImportDotenv(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	t.Run("with a valid file", func(t *testing.T) {
+		vs, err := client.Variables.ImportDotenv(ctx, wTest.ID, []byte("FOO=bar\n"))
+		require.NoError(t, err)
+		require.Len(t, vs, 1)
+		assert.Equal(t, "FOO", vs[0].Key)
+		assert.Equal(t, "bar", vs[0].Value)
+		assert.Equal(t, CategoryEnv, vs[0].Category)
+	})
+
+	t.Run("with an invalid file", func(t *testing.T) {
+		vs, err := client.Variables.ImportDotenv(ctx, wTest.ID, []byte("FOO"))
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "line 1: expected KEY=VALUE")
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		vs, err := client.Variables.ImportDotenv(ctx, badIdentifier, []byte("FOO=bar"))
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestVariablesImportFile(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	t.Run("with a valid file", func(t *testing.T) {
+		vs, err := client.Variables.ImportFile(ctx, wTest.ID, []byte(
+			"variables:\n  - key: region\n    value: eu-west-1\n    category: terraform\n",
+		), "yaml")
+		require.NoError(t, err)
+		require.Len(t, vs, 1)
+		assert.Equal(t, "region", vs[0].Key)
+		assert.Equal(t, "eu-west-1", vs[0].Value)
+		assert.Equal(t, CategoryTerraform, vs[0].Category)
+	})
+
+	t.Run("with an invalid file", func(t *testing.T) {
+		vs, err := client.Variables.ImportFile(ctx, wTest.ID, []byte(`{"variables": [{"key": "a"}]}`), "json")
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "variables[0].category: is required")
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		vs, err := client.Variables.ImportFile(ctx, badIdentifier, []byte(""), "yaml")
+		assert.Nil(t, vs)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestVariablesSchemaJSON(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	for _, options := range []VariableCreateOptions{
+		{Key: String("name"), Value: String("foo"), Category: Category(CategoryTerraform)},
+		{Key: String("TOKEN"), Value: String("hidden"), Category: Category(CategoryEnv), Sensitive: Bool(true)},
+	} {
+		options.Workspace = wTest
+		_, err := client.Variables.Create(ctx, options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with valid options", func(t *testing.T) {
+		data, err := client.Variables.SchemaJSON(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+
+		var schema struct {
+			Properties map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(data, &schema))
+
+		name := schema.Properties["terraform"].Properties["name"]
+		require.NotNil(t, name)
+		assert.Equal(t, "foo", name["default"])
+		assert.Equal(t, false, name["x-tfe-sensitive"])
+		assert.Equal(t, false, name["x-tfe-hcl"])
+
+		token := schema.Properties["env"].Properties["TOKEN"]
+		require.NotNil(t, token)
+		assert.NotContains(t, token, "default")
+		assert.Equal(t, true, token["writeOnly"])
+		assert.Equal(t, true, token["x-tfe-sensitive"])
+	})
+
+	t.Run("when options is missing an organization", func(t *testing.T) {
+		data, err := client.Variables.SchemaJSON(ctx, VariableListOptions{
+			Workspace: String(wTest.Name),
+		})
+		assert.Nil(t, data)
+		assert.EqualError(t, err, "organization is required")
+	})
+}
+
+func TestVariablesCheckHCL(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	for _, options := range []VariableCreateOptions{
+		{Key: String("valid"), Value: String(`["a", "b"]`), HCL: Bool(true)},
+		{Key: String("invalid"), Value: String("{\n  foo = [1, 2\n}"), HCL: Bool(true)},
+		{Key: String("plain"), Value: String("[not hcl"), HCL: Bool(false)},
+	} {
+		options.Category = Category(CategoryTerraform)
+		options.Workspace = wTest
+		_, err := client.Variables.Create(ctx, options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with valid options", func(t *testing.T) {
+		results, err := client.Variables.CheckHCL(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.Equal(t, "invalid", results[0].Variable.Key)
+		assert.False(t, results[0].Valid())
+		assert.Equal(t, `3:1: Missing item separator`, results[0].Error.Error())
+
+		assert.Equal(t, "valid", results[1].Variable.Key)
+		assert.True(t, results[1].Valid())
+	})
+
+	t.Run("when options is missing an organization", func(t *testing.T) {
+		results, err := client.Variables.CheckHCL(ctx, VariableListOptions{
+			Workspace: String(wTest.Name),
+		})
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "organization is required")
+	})
+}
+
+func TestVariablesExport(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	for _, options := range []VariableCreateOptions{
+		{Key: String("name"), Value: String("foo"), Category: Category(CategoryTerraform)},
+		{Key: String("TOKEN"), Value: String("hidden"), Category: Category(CategoryEnv), Sensitive: Bool(true)},
+	} {
+		options.Workspace = wTest
+		_, err := client.Variables.Create(ctx, options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with valid options", func(t *testing.T) {
+		exports, err := client.Variables.Export(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []VariableExport{
+			{Key: "TOKEN", Category: CategoryEnv, Sensitive: true},
+			{Key: "name", Value: "foo", Category: CategoryTerraform},
+		}, exports)
+	})
+
+	t.Run("when options is missing an organization", func(t *testing.T) {
+		exports, err := client.Variables.Export(ctx, VariableListOptions{
+			Workspace: String(wTest.Name),
+		})
+		assert.Nil(t, exports)
+		assert.EqualError(t, err, "organization is required")
+	})
+}
+
+func TestVariablesStreamOrganization(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, _ := createWorkspace(t, client, orgTest)
+	wTest2, _ := createWorkspace(t, client, orgTest)
+
+	vTest1, _ := createVariable(t, client, wTest1)
+	vTest2, _ := createVariable(t, client, wTest1)
+	vTest3, _ := createVariable(t, client, wTest2)
+
+	t.Run("with a valid organization", func(t *testing.T) {
+		vc, errc := client.Variables.StreamOrganization(ctx, orgTest.Name)
+
+		found := make(map[string]string)
+		for v := range vc {
+			found[v.Variable.ID] = v.Workspace.ID
+		}
+		require.NoError(t, <-errc)
+
+		assert.Equal(t, map[string]string{
+			vTest1.ID: wTest1.ID,
+			vTest2.ID: wTest1.ID,
+			vTest3.ID: wTest2.ID,
+		}, found)
+	})
+
+	t.Run("when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		vc, errc := client.Variables.StreamOrganization(ctx, orgTest.Name)
+
+		// Read a single variable and stop consuming.
+		<-vc
+		cancel()
+
+		assert.Equal(t, context.Canceled, <-errc)
+
+		_, ok := <-vc
+		assert.False(t, ok)
+	})
+
+	t.Run("without a valid organization", func(t *testing.T) {
+		vc, errc := client.Variables.StreamOrganization(ctx, badIdentifier)
+
+		_, ok := <-vc
+		assert.False(t, ok)
+		assert.EqualError(t, <-errc, "invalid value for organization")
+	})
+}
+
+func TestVariablesFindByKey(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, _ := createWorkspace(t, client, orgTest)
+	wTest2, _ := createWorkspace(t, client, orgTest)
+	createWorkspace(t, client, orgTest)
+
+	var created []*Variable
+	for _, w := range []*Workspace{wTest1, wTest2} {
+		v, err := client.Variables.Create(ctx, VariableCreateOptions{
+			Key:       String("AWS_REGION"),
+			Value:     String("us-east-1"),
+			Category:  Category(CategoryEnv),
+			Workspace: w,
+		})
+		require.NoError(t, err)
+		created = append(created, v)
+	}
+	createVariable(t, client, wTest1)
+
+	t.Run("with an existing key", func(t *testing.T) {
+		found, err := client.Variables.FindByKey(ctx, orgTest.Name, "AWS_REGION")
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+
+		workspaces := make(map[string]string)
+		for _, v := range found {
+			workspaces[v.Variable.ID] = v.Workspace.ID
+		}
+		assert.Equal(t, map[string]string{
+			created[0].ID: wTest1.ID,
+			created[1].ID: wTest2.ID,
+		}, workspaces)
+	})
+
+	t.Run("with an unknown key", func(t *testing.T) {
+		found, err := client.Variables.FindByKey(ctx, orgTest.Name, "UNKNOWN")
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("without a key", func(t *testing.T) {
+		found, err := client.Variables.FindByKey(ctx, orgTest.Name, "")
+		assert.Nil(t, found)
+		assert.EqualError(t, err, "key is required")
+	})
+}
+
+func TestVariablesFindByKeyStream(t *testing.T) {
+	vars := map[string]string{
+		"zulu": `[
+			{"type": "vars", "id": "var-1", "attributes": {"key": "AWS_REGION", "category": "env"}},
+			{"type": "vars", "id": "var-2", "attributes": {"key": "other", "category": "terraform"}}
+		]`,
+		"alpha": `[
+			{"type": "vars", "id": "var-3", "attributes": {"key": "AWS_REGION", "category": "terraform"}}
+		]`,
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+
+		switch r.URL.Path {
+		case "/api/v2/organizations/org-test/workspaces":
+			w.Write([]byte(`{"data": [
+				{"type": "workspaces", "id": "ws-1", "attributes": {"name": "zulu"}},
+				{"type": "workspaces", "id": "ws-2", "attributes": {"name": "alpha"}},
+				{"type": "workspaces", "id": "ws-3", "attributes": {"name": "broken"}}
+			]}`))
+		case "/api/v2/vars":
+			data, ok := vars[r.URL.Query().Get("filter[workspace][name]")]
+			if !ok {
+				w.WriteHeader(404)
+				return
+			}
+			w.Write([]byte(`{"data": ` + data + `}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	t.Run("when walking the organization fails", func(t *testing.T) {
+		found, err := client.Variables.FindByKey(context.Background(), "org-test", "AWS_REGION")
+		assert.Nil(t, found)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("with an existing key", func(t *testing.T) {
+		vars["broken"] = `[]`
+
+		found, err := client.Variables.FindByKey(context.Background(), "org-test", "AWS_REGION")
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, "var-3", found[0].Variable.ID)
+		assert.Equal(t, "alpha", found[0].Workspace.Name)
+		assert.Equal(t, "var-1", found[1].Variable.ID)
+		assert.Equal(t, "zulu", found[1].Workspace.Name)
+	})
+}
+func TestVariablesStaleSensitive(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	_, err := client.Variables.Create(ctx, VariableCreateOptions{
+		Key:       String("name"),
+		Value:     String("foo"),
+		Category:  Category(CategoryTerraform),
+		Workspace: wTest,
+	})
+	require.NoError(t, err)
+
+	vTest, err := client.Variables.Create(ctx, VariableCreateOptions{
+		Key:       String("TOKEN"),
+		Value:     String("hidden"),
+		Category:  Category(CategoryEnv),
+		Sensitive: Bool(true),
+		Workspace: wTest,
+	})
+	require.NoError(t, err)
+
+	t.Run("when the sensitive variables are stale", func(t *testing.T) {
+		vars, err := client.Variables.StaleSensitive(ctx, wTest.ID, 0)
+		require.NoError(t, err)
+		require.Len(t, vars, 1)
+		assert.Equal(t, "TOKEN", vars[0].Key)
+		assert.Empty(t, vars[0].Value)
+	})
+
+	t.Run("when the sensitive variables were updated recently", func(t *testing.T) {
+		if vTest.UpdatedAt.IsZero() {
+			t.Skip("the API does not report when variables were updated")
+		}
+
+		vars, err := client.Variables.StaleSensitive(ctx, wTest.ID, time.Hour)
+		require.NoError(t, err)
+		assert.Empty(t, vars)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		vars, err := client.Variables.StaleSensitive(ctx, badIdentifier, time.Hour)
+		assert.Nil(t, vars)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestVariablesSummary(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	for _, options := range []VariableCreateOptions{
+		{Key: String("name"), Value: String("foo"), Category: Category(CategoryTerraform)},
+		{Key: String("region"), Value: String("bar"), Category: Category(CategoryTerraform), Sensitive: Bool(true)},
+		{Key: String("TOKEN"), Value: String("hidden"), Category: Category(CategoryEnv), Sensitive: Bool(true)},
+	} {
+		options.Workspace = wTest
+		_, err := client.Variables.Create(ctx, options)
+		require.NoError(t, err)
+	}
+
+	t.Run("with valid options", func(t *testing.T) {
+		summary, err := client.Variables.Summary(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+			Workspace:    String(wTest.Name),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &VariableSummary{
+			Total:     3,
+			Terraform: 2,
+			Env:       1,
+			Sensitive: 2,
+		}, summary)
+	})
+
+	t.Run("without a workspace", func(t *testing.T) {
+		summary, err := client.Variables.Summary(ctx, VariableListOptions{
+			Organization: String(orgTest.Name),
+		})
+		assert.Nil(t, summary)
+		assert.EqualError(t, err, "workspace is required")
+	})
+}
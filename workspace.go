@@ -1,10 +1,15 @@
 package tfe
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -45,6 +50,57 @@ type Workspaces interface {
 
 	// UnassignSSHKey from a workspace.
 	UnassignSSHKey(ctx context.Context, workspaceID string) (*Workspace, error)
+
+	// OutputValue reads a single output of the current state of a workspace.
+	OutputValue(ctx context.Context, workspaceID string, outputName string) (*StateVersionOutput, error)
+
+	// TeamAccessList lists all teams with access to a workspace.
+	TeamAccessList(ctx context.Context, workspaceID string) ([]*TeamAccess, error)
+
+	// CurrentRuns reads the current run of multiple workspaces.
+	CurrentRuns(ctx context.Context, workspaceIDs []string) (map[string]*Run, error)
+
+	// RemoteStateConsumers lists the workspaces that can access the state of
+	// a workspace.
+	RemoteStateConsumers(ctx context.Context, workspaceID string, options RemoteStateConsumersListOptions) (*WorkspaceList, error)
+
+	// AddRemoteStateConsumers adds remote state consumers to a workspace.
+	AddRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceAddRemoteStateConsumersOptions) error
+
+	// RemoveRemoteStateConsumers removes remote state consumers from a
+	// workspace.
+	RemoveRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceRemoveRemoteStateConsumersOptions) error
+
+	// UpdateRemoteStateConsumers replaces the remote state consumers of a
+	// workspace.
+	UpdateRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceUpdateRemoteStateConsumersOptions) error
+
+	// CancelPendingRuns cancels or discards all pending runs of a workspace.
+	CancelPendingRuns(ctx context.Context, workspaceID string, comment string) ([]string, error)
+
+	// CreateWithVariables creates a workspace together with its variables.
+	CreateWithVariables(ctx context.Context, organization string, options WorkspaceCreateOptions, vars []VariableCreateOptions, rollback bool) (*Workspace, []*Variable, error)
+
+	// SetAssessmentsEnabled enables or disables health assessments for
+	// multiple workspaces.
+	SetAssessmentsEnabled(ctx context.Context, workspaceIDs []string, enabled bool) error
+
+	// AddTags adds tags to multiple workspaces.
+	AddTags(ctx context.Context, workspaceIDs []string, tags []string) error
+
+	// RemoveTags removes tags from multiple workspaces.
+	RemoveTags(ctx context.Context, workspaceIDs []string, tags []string) error
+
+	// Preflight checks if runs can be queued on a workspace.
+	Preflight(ctx context.Context, workspaceID string) (*PreflightResult, error)
+
+	// EffectiveVariables returns the variables of a workspace merged with
+	// the variables of the variable sets applied to it.
+	EffectiveVariables(ctx context.Context, workspaceID string) ([]*Variable, error)
+
+	// SafeDelete deletes a workspace by its ID, unless other workspaces
+	// consume its state.
+	SafeDelete(ctx context.Context, workspaceID string, options WorkspaceSafeDeleteOptions) error
 }
 
 // workspaces implements Workspaces.
@@ -62,16 +118,22 @@ type WorkspaceList struct {
 type Workspace struct {
 	ID                   string                `jsonapi:"primary,workspaces"`
 	Actions              *WorkspaceActions     `jsonapi:"attr,actions"`
+	AssessmentsEnabled   bool                  `jsonapi:"attr,assessments-enabled"`
 	AutoApply            bool                  `jsonapi:"attr,auto-apply"`
+	AutoApplyRunTrigger  bool                  `jsonapi:"attr,auto-apply-run-trigger"`
 	CanQueueDestroyPlan  bool                  `jsonapi:"attr,can-queue-destroy-plan"`
 	CreatedAt            time.Time             `jsonapi:"attr,created-at,iso8601"`
 	Environment          string                `jsonapi:"attr,environment"`
+	GlobalRemoteState    bool                  `jsonapi:"attr,global-remote-state"`
 	Locked               bool                  `jsonapi:"attr,locked"`
 	MigrationEnvironment string                `jsonapi:"attr,migration-environment"`
 	Name                 string                `jsonapi:"attr,name"`
 	Operations           bool                  `jsonapi:"attr,operations"`
 	Permissions          *WorkspacePermissions `jsonapi:"attr,permissions"`
 	QueueAllRuns         bool                  `jsonapi:"attr,queue-all-runs"`
+	SourceName           string                `jsonapi:"attr,source-name"`
+	SourceURL            string                `jsonapi:"attr,source-url"`
+	TagNames             []string              `jsonapi:"attr,tag-names"`
 	TerraformVersion     string                `jsonapi:"attr,terraform-version"`
 	VCSRepo              *VCSRepo              `jsonapi:"attr,vcs-repo"`
 	WorkingDirectory     string                `jsonapi:"attr,working-directory"`
@@ -79,9 +141,16 @@ type Workspace struct {
 	// Relations
 	CurrentRun   *Run          `jsonapi:"relation,current-run"`
 	Organization *Organization `jsonapi:"relation,organization"`
+	Project      *Project      `jsonapi:"relation,project"`
 	SSHKey       *SSHKey       `jsonapi:"relation,ssh-key"`
 }
 
+// Tag represents a workspace tag.
+type Tag struct {
+	ID   string `jsonapi:"primary,tags"`
+	Name string `jsonapi:"attr,name,omitempty"`
+}
+
 // VCSRepo contains the configuration of a VCS integration.
 type VCSRepo struct {
 	Branch            string `json:"branch"`
@@ -112,6 +181,11 @@ type WorkspaceListOptions struct {
 
 	// A search string (partial workspace name) used to filter the results.
 	Search *string `url:"search[name],omitempty"`
+
+	// A comma-separated list of related resources to include. Use
+	// "current_run" to include the current run of each workspace, so that
+	// its status is available without reading each run separately.
+	Include *string `url:"include,omitempty"`
 }
 
 // List all the workspaces within an organization.
@@ -120,7 +194,7 @@ func (s *workspaces) List(ctx context.Context, organization string, options Work
 		return nil, errors.New("invalid value for organization")
 	}
 
-	u := fmt.Sprintf("organizations/%s/workspaces", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/workspaces", url.PathEscape(organization))
 	req, err := s.client.newRequest("GET", u, &options)
 	if err != nil {
 		return nil, err
@@ -128,11 +202,34 @@ func (s *workspaces) List(ctx context.Context, organization string, options Work
 
 	wl := &WorkspaceList{}
 	err = s.client.do(ctx, req, wl)
-	if err != nil {
+	if err != nil && !isParseError(err) {
 		return nil, err
 	}
 
-	return wl, nil
+	return wl, err
+}
+
+// listAllWorkspaces retrieves all the workspaces of the given organization
+// by walking through all available pages.
+func (c *Client) listAllWorkspaces(ctx context.Context, organization string) ([]*Workspace, error) {
+	var workspaces []*Workspace
+
+	options := WorkspaceListOptions{}
+	for {
+		wl, err := c.Workspaces.List(ctx, organization, options)
+		if err != nil {
+			return nil, err
+		}
+
+		workspaces = append(workspaces, wl.Items...)
+
+		if wl.Pagination == nil || wl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = wl.NextPage
+	}
+
+	return workspaces, nil
 }
 
 // WorkspaceCreateOptions represents the options for creating a new workspace.
@@ -140,9 +237,22 @@ type WorkspaceCreateOptions struct {
 	// For internal use only!
 	ID string `jsonapi:"primary,workspaces"`
 
+	// Whether health assessments (such as drift detection) are enabled for
+	// the workspace.
+	AssessmentsEnabled *bool `jsonapi:"attr,assessments-enabled,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attr,auto-apply,omitempty"`
 
+	// Whether to automatically apply changes for runs that were created by
+	// a run trigger from another workspace. This is independent of AutoApply.
+	AutoApplyRunTrigger *bool `jsonapi:"attr,auto-apply-run-trigger,omitempty"`
+
+	// Whether the state of this workspace can be accessed by all workspaces in
+	// the organization. When false, only the workspaces configured as remote
+	// state consumers can access it.
+	GlobalRemoteState *bool `jsonapi:"attr,global-remote-state,omitempty"`
+
 	// The legacy TFE environment to use as the source of the migration, in the
 	// form organization/environment. Omit this unless you are migrating a legacy
 	// environment.
@@ -153,10 +263,21 @@ type WorkspaceCreateOptions struct {
 	// organization.
 	Name *string `jsonapi:"attr,name"`
 
+	// Whether the workspace will use remote or local execution mode.
+	Operations *bool `jsonapi:"attr,operations,omitempty"`
+
 	// Whether to queue all runs. Unless this is set to true, runs triggered by
 	// a webhook will not be queued until at least one run is manually queued.
 	QueueAllRuns *bool `jsonapi:"attr,queue-all-runs,omitempty"`
 
+	// A friendly name for the application or client creating the workspace,
+	// shown in the UI. Setting this requires SourceURL to be set as well.
+	SourceName *string `jsonapi:"attr,source-name,omitempty"`
+
+	// A URL for the application or client creating the workspace, linked to
+	// from the UI. This must be an absolute http or https URL.
+	SourceURL *string `jsonapi:"attr,source-url,omitempty"`
+
 	// The version of Terraform to use for this workspace. Upon creating a
 	// workspace, the latest version is selected unless otherwise specified.
 	TerraformVersion *string `jsonapi:"attr,terraform-version,omitempty"`
@@ -187,6 +308,20 @@ func (o WorkspaceCreateOptions) valid() error {
 	if !validStringID(o.Name) {
 		return errors.New("invalid value for name")
 	}
+	if o.WorkingDirectory != nil && !validRelativePath(o.WorkingDirectory) {
+		return errors.New("working directory must be a relative path")
+	}
+	return validSource(o.SourceName, o.SourceURL)
+}
+
+// validSource validates the source name and URL options of a workspace.
+func validSource(name, u *string) error {
+	if name != nil && u == nil {
+		return errors.New("source URL is required when setting a source name")
+	}
+	if u != nil && !validURL(u) {
+		return errors.New("invalid value for source URL")
+	}
 	return nil
 }
 
@@ -202,7 +337,7 @@ func (s *workspaces) Create(ctx context.Context, organization string, options Wo
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("organizations/%s/workspaces", url.QueryEscape(organization))
+	u := fmt.Sprintf("organizations/%s/workspaces", url.PathEscape(organization))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -222,14 +357,14 @@ func (s *workspaces) Read(ctx context.Context, organization, workspace string) (
 	if !validStringID(&organization) {
 		return nil, errors.New("invalid value for organization")
 	}
-	if !validStringID(&workspace) {
+	if !validPathSegment(&workspace) {
 		return nil, errors.New("invalid value for workspace")
 	}
 
 	u := fmt.Sprintf(
 		"organizations/%s/workspaces/%s",
-		url.QueryEscape(organization),
-		url.QueryEscape(workspace),
+		url.PathEscape(organization),
+		url.PathEscape(workspace),
 	)
 	req, err := s.client.newRequest("GET", u, nil)
 	if err != nil {
@@ -245,24 +380,81 @@ func (s *workspaces) Read(ctx context.Context, organization, workspace string) (
 	return w, nil
 }
 
+// workspaceReadOptions represents the options for reading a workspace.
+type workspaceReadOptions struct {
+	// A comma-separated list of related resources to include.
+	Include string `url:"include,omitempty"`
+}
+
+// readWorkspaceByID reads a workspace by its ID, for services that only
+// know the ID of a workspace.
+func (c *Client) readWorkspaceByID(ctx context.Context, workspaceID string) (*Workspace, error) {
+	return (&workspaces{client: c}).readByID(ctx, workspaceID, workspaceReadOptions{})
+}
+
+// readByID reads a workspace by its ID.
+func (s *workspaces) readByID(ctx context.Context, workspaceID string, options workspaceReadOptions) (*Workspace, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{}
+	err = s.client.do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
 // WorkspaceUpdateOptions represents the options for updating a workspace.
 type WorkspaceUpdateOptions struct {
 	// For internal use only!
 	ID string `jsonapi:"primary,workspaces"`
 
+	// Whether health assessments (such as drift detection) are enabled for
+	// the workspace.
+	AssessmentsEnabled *bool `jsonapi:"attr,assessments-enabled,omitempty"`
+
 	// Whether to automatically apply changes when a Terraform plan is successful.
 	AutoApply *bool `jsonapi:"attr,auto-apply,omitempty"`
 
+	// Whether to automatically apply changes for runs that were created by
+	// a run trigger from another workspace. This is independent of AutoApply.
+	AutoApplyRunTrigger *bool `jsonapi:"attr,auto-apply-run-trigger,omitempty"`
+
+	// Whether the state of this workspace can be accessed by all workspaces in
+	// the organization. When false, only the workspaces configured as remote
+	// state consumers can access it.
+	GlobalRemoteState *bool `jsonapi:"attr,global-remote-state,omitempty"`
+
 	// A new name for the workspace, which can only include letters, numbers, -,
 	// and _. This will be used as an identifier and must be unique in the
 	// organization. Warning: Changing a workspace's name changes its URL in the
 	// API and UI.
 	Name *string `jsonapi:"attr,name,omitempty"`
 
+	// Whether the workspace will use remote or local execution mode.
+	Operations *bool `jsonapi:"attr,operations,omitempty"`
+
 	// Whether to queue all runs. Unless this is set to true, runs triggered by
 	// a webhook will not be queued until at least one run is manually queued.
 	QueueAllRuns *bool `jsonapi:"attr,queue-all-runs,omitempty"`
 
+	// A friendly name for the application or client that created the
+	// workspace, shown in the UI.
+	SourceName *string `jsonapi:"attr,source-name,omitempty"`
+
+	// A URL for the application or client that created the workspace,
+	// linked to from the UI. This must be an absolute http or https URL.
+	SourceURL *string `jsonapi:"attr,source-url,omitempty"`
+
 	// The version of Terraform to use for this workspace.
 	TerraformVersion *string `jsonapi:"attr,terraform-version,omitempty"`
 
@@ -280,22 +472,35 @@ type WorkspaceUpdateOptions struct {
 	WorkingDirectory *string `jsonapi:"attr,working-directory,omitempty"`
 }
 
+func (o WorkspaceUpdateOptions) valid() error {
+	if o.WorkingDirectory != nil && !validRelativePath(o.WorkingDirectory) {
+		return errors.New("working directory must be a relative path")
+	}
+	if o.SourceURL != nil && !validURL(o.SourceURL) {
+		return errors.New("invalid value for source URL")
+	}
+	return nil
+}
+
 // Update settings of an existing workspace.
 func (s *workspaces) Update(ctx context.Context, organization, workspace string, options WorkspaceUpdateOptions) (*Workspace, error) {
 	if !validStringID(&organization) {
 		return nil, errors.New("invalid value for organization")
 	}
-	if !validStringID(&workspace) {
+	if !validPathSegment(&workspace) {
 		return nil, errors.New("invalid value for workspace")
 	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
 
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
 	u := fmt.Sprintf(
 		"organizations/%s/workspaces/%s",
-		url.QueryEscape(organization),
-		url.QueryEscape(workspace),
+		url.PathEscape(organization),
+		url.PathEscape(workspace),
 	)
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
@@ -311,19 +516,46 @@ func (s *workspaces) Update(ctx context.Context, organization, workspace string,
 	return w, nil
 }
 
+// updateByID updates the settings of a workspace by its ID.
+func (s *workspaces) updateByID(ctx context.Context, workspaceID string, options WorkspaceUpdateOptions) (*Workspace, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if err := options.valid(); err != nil {
+		return nil, err
+	}
+
+	// Make sure we don't send a user provided ID.
+	options.ID = ""
+
+	u := fmt.Sprintf("workspaces/%s", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("PATCH", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{}
+	err = s.client.do(ctx, req, w)
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
 // Delete a workspace by its name.
 func (s *workspaces) Delete(ctx context.Context, organization, workspace string) error {
 	if !validStringID(&organization) {
 		return errors.New("invalid value for organization")
 	}
-	if !validStringID(&workspace) {
+	if !validPathSegment(&workspace) {
 		return errors.New("invalid value for workspace")
 	}
 
 	u := fmt.Sprintf(
 		"organizations/%s/workspaces/%s",
-		url.QueryEscape(organization),
-		url.QueryEscape(workspace),
+		url.PathEscape(organization),
+		url.PathEscape(workspace),
 	)
 	req, err := s.client.newRequest("DELETE", u, nil)
 	if err != nil {
@@ -345,7 +577,7 @@ func (s *workspaces) Lock(ctx context.Context, workspaceID string, options Works
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/actions/lock", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/actions/lock", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("POST", u, &options)
 	if err != nil {
 		return nil, err
@@ -366,7 +598,7 @@ func (s *workspaces) Unlock(ctx context.Context, workspaceID string) (*Workspace
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/actions/unlock", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/actions/unlock", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("POST", u, nil)
 	if err != nil {
 		return nil, err
@@ -387,7 +619,7 @@ func (s *workspaces) ForceUnlock(ctx context.Context, workspaceID string) (*Work
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/actions/force-unlock", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/actions/force-unlock", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("POST", u, nil)
 	if err != nil {
 		return nil, err
@@ -434,7 +666,7 @@ func (s *workspaces) AssignSSHKey(ctx context.Context, workspaceID string, optio
 	// Make sure we don't send a user provided ID.
 	options.ID = ""
 
-	u := fmt.Sprintf("workspaces/%s/relationships/ssh-key", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/relationships/ssh-key", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("PATCH", u, &options)
 	if err != nil {
 		return nil, err
@@ -465,7 +697,7 @@ func (s *workspaces) UnassignSSHKey(ctx context.Context, workspaceID string) (*W
 		return nil, errors.New("invalid value for workspace ID")
 	}
 
-	u := fmt.Sprintf("workspaces/%s/relationships/ssh-key", url.QueryEscape(workspaceID))
+	u := fmt.Sprintf("workspaces/%s/relationships/ssh-key", url.PathEscape(workspaceID))
 	req, err := s.client.newRequest("PATCH", u, &workspaceUnassignSSHKeyOptions{})
 	if err != nil {
 		return nil, err
@@ -479,3 +711,734 @@ func (s *workspaces) UnassignSSHKey(ctx context.Context, workspaceID string) (*W
 
 	return w, nil
 }
+
+// OutputValue reads a single output by its name from the current state
+// version of the given workspace. The value of a sensitive output is never
+// returned. If the workspace has no current state or the state does not
+// contain the named output, ErrResourceNotFound is returned.
+func (s *workspaces) OutputValue(ctx context.Context, workspaceID, outputName string) (*StateVersionOutput, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if outputName == "" {
+		return nil, errors.New("output name is required")
+	}
+
+	options := struct {
+		Include string `url:"include"`
+	}{
+		Include: "outputs",
+	}
+
+	u := fmt.Sprintf("workspaces/%s/current-state-version", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("GET", u, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Outputs can hold values of any type, so we decode the
+	// included outputs ourselves instead of using jsonapi.
+	var buf bytes.Buffer
+	err = s.client.do(ctx, req, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Included []struct {
+			ID         string `json:"id"`
+			Type       string `json:"type"`
+			Attributes struct {
+				Name      string      `json:"name"`
+				Sensitive bool        `json:"sensitive"`
+				Type      string      `json:"type"`
+				Value     interface{} `json:"value"`
+			} `json:"attributes"`
+		} `json:"included"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		return nil, err
+	}
+
+	for _, inc := range raw.Included {
+		if inc.Type != "state-version-outputs" || inc.Attributes.Name != outputName {
+			continue
+		}
+
+		svo := &StateVersionOutput{
+			ID:        inc.ID,
+			Name:      inc.Attributes.Name,
+			Sensitive: inc.Attributes.Sensitive,
+			Type:      inc.Attributes.Type,
+		}
+
+		// Make sure we never return the value of a sensitive output.
+		if !svo.Sensitive {
+			svo.Value = inc.Attributes.Value
+		}
+
+		return svo, nil
+	}
+
+	return nil, ErrResourceNotFound
+}
+
+// TeamAccessList returns the access of all teams that have access to the
+// given workspace, walking through all available pages. The team relation of
+// every returned team access is fully populated, as the teams are included
+// in the list.
+func (s *workspaces) TeamAccessList(ctx context.Context, workspaceID string) ([]*TeamAccess, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	options := TeamAccessListOptions{
+		WorkspaceID: String(workspaceID),
+		Include:     String("team"),
+	}
+
+	var result []*TeamAccess
+	for {
+		tal, err := s.client.TeamAccess.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, tal.Items...)
+
+		if tal.Pagination == nil || tal.NextPage == 0 {
+			break
+		}
+		options.PageNumber = tal.NextPage
+	}
+
+	return result, nil
+}
+
+// CurrentRuns reads the current run of each of the given workspaces. The
+// workspaces are read concurrently and the result is keyed by workspace ID.
+// Workspaces without a current run, or for which reading the current run
+// failed, have a nil entry. Failures do not abort the other reads, but are
+// returned together as a *BulkError keyed by workspace ID.
+func (s *workspaces) CurrentRuns(ctx context.Context, workspaceIDs []string) (map[string]*Run, error) {
+	for _, workspaceID := range workspaceIDs {
+		if !validStringID(&workspaceID) {
+			return nil, errors.New("invalid value for workspace ID")
+		}
+	}
+	return s.currentRuns(ctx, bulkIndexes(len(workspaceIDs)), workspaceIDs)
+}
+
+// currentRuns reads the current run of each of the given workspaces into a
+// new map, where indexes holds the index of each workspace ID in the IDs of
+// the original call.
+func (s *workspaces) currentRuns(ctx context.Context, indexes []int, workspaceIDs []string) (map[string]*Run, error) {
+	var mu sync.Mutex
+	runs := make(map[string]*Run, len(workspaceIDs))
+	for _, workspaceID := range workspaceIDs {
+		runs[workspaceID] = nil
+	}
+
+	err := forEachIndexed(ctx, indexes, workspaceIDs, nil, func(ctx context.Context, workspaceID string) error {
+		w, err := s.readByID(ctx, workspaceID, workspaceReadOptions{Include: "current_run"})
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		runs[workspaceID] = w.CurrentRun
+		mu.Unlock()
+
+		return nil
+	})
+
+	return runs, withRetry(err, func(ctx context.Context, indexes []int, ids []string) (interface{}, error) {
+		return s.currentRuns(ctx, indexes, ids)
+	})
+}
+
+// RemoteStateConsumersListOptions represents the options for listing the
+// remote state consumers of a workspace.
+type RemoteStateConsumersListOptions struct {
+	ListOptions
+}
+
+// RemoteStateConsumers lists the workspaces that are allowed to access the
+// state of the given workspace. This only applies when the workspace does
+// not share its state globally.
+func (s *workspaces) RemoteStateConsumers(ctx context.Context, workspaceID string, options RemoteStateConsumersListOptions) (*WorkspaceList, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/remote-state-consumers", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("GET", u, &options)
+	if err != nil {
+		return nil, err
+	}
+
+	wl := &WorkspaceList{}
+	err = s.client.do(ctx, req, wl)
+	if err != nil && !isParseError(err) {
+		return nil, err
+	}
+
+	return wl, err
+}
+
+// WorkspaceAddRemoteStateConsumersOptions represents the options for adding
+// remote state consumers to a workspace.
+type WorkspaceAddRemoteStateConsumersOptions struct {
+	// The workspaces to add as remote state consumers.
+	Workspaces []*Workspace
+}
+
+func (o WorkspaceAddRemoteStateConsumersOptions) valid() error {
+	return validRemoteStateConsumers(o.Workspaces)
+}
+
+// AddRemoteStateConsumers adds remote state consumers to a workspace.
+func (s *workspaces) AddRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceAddRemoteStateConsumersOptions) error {
+	if !validStringID(&workspaceID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if err := options.valid(); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/remote-state-consumers", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("POST", u, options.Workspaces)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// WorkspaceRemoveRemoteStateConsumersOptions represents the options for
+// removing remote state consumers from a workspace.
+type WorkspaceRemoveRemoteStateConsumersOptions struct {
+	// The workspaces to remove as remote state consumers.
+	Workspaces []*Workspace
+}
+
+func (o WorkspaceRemoveRemoteStateConsumersOptions) valid() error {
+	return validRemoteStateConsumers(o.Workspaces)
+}
+
+// RemoveRemoteStateConsumers removes remote state consumers from a
+// workspace.
+func (s *workspaces) RemoveRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceRemoveRemoteStateConsumersOptions) error {
+	if !validStringID(&workspaceID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if err := options.valid(); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/remote-state-consumers", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("DELETE", u, options.Workspaces)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// WorkspaceUpdateRemoteStateConsumersOptions represents the options for
+// replacing the remote state consumers of a workspace.
+type WorkspaceUpdateRemoteStateConsumersOptions struct {
+	// The workspaces that will be the only remote state consumers.
+	Workspaces []*Workspace
+}
+
+func (o WorkspaceUpdateRemoteStateConsumersOptions) valid() error {
+	// An empty list is allowed and removes all remote state consumers.
+	if o.Workspaces != nil && len(o.Workspaces) == 0 {
+		return nil
+	}
+	return validRemoteStateConsumers(o.Workspaces)
+}
+
+// UpdateRemoteStateConsumers replaces all remote state consumers of a
+// workspace with the given workspaces. Passing an empty list removes all
+// remote state consumers.
+func (s *workspaces) UpdateRemoteStateConsumers(ctx context.Context, workspaceID string, options WorkspaceUpdateRemoteStateConsumersOptions) error {
+	if !validStringID(&workspaceID) {
+		return errors.New("invalid value for workspace ID")
+	}
+	if err := options.valid(); err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("workspaces/%s/relationships/remote-state-consumers", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("PATCH", u, options.Workspaces)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// validRemoteStateConsumers validates the workspaces passed as remote state
+// consumers.
+func validRemoteStateConsumers(workspaces []*Workspace) error {
+	if workspaces == nil {
+		return errors.New("workspaces is required")
+	}
+	if len(workspaces) == 0 {
+		return errors.New("must provide at least one workspace")
+	}
+	for _, w := range workspaces {
+		if w == nil || !validStringID(&w.ID) {
+			return errors.New("invalid value for workspace ID")
+		}
+	}
+	return nil
+}
+
+// CancelPendingRuns stops all runs of the given workspace that are pending,
+// queued or waiting for confirmation, using the (optional) comment as the
+// reason. Runs that can be canceled are canceled, and runs that can only be
+// discarded, such as runs waiting for confirmation, are discarded. It
+// returns the IDs of the canceled and discarded runs. When stopping a run
+// fails, the IDs of the runs that were already stopped are returned
+// together with the error. When Config.ConfirmDestructive is enabled, ctx
+// must be confirmed using WithDestructiveConfirmed.
+func (s *workspaces) CancelPendingRuns(ctx context.Context, workspaceID string, comment string) ([]string, error) {
+	if !validStringID(&workspaceID) {
+		return nil, errors.New("invalid value for workspace ID")
+	}
+	if err := s.client.checkDestructive(ctx); err != nil {
+		return nil, err
+	}
+
+	var pending []*Run
+
+	options := RunListOptions{}
+	for {
+		rl, err := s.client.Runs.List(ctx, workspaceID, options)
+		if err != nil {
+			return nil, err
+		}
+
+		finished := true
+		for _, r := range rl.Items {
+			if !r.Status.finished() {
+				finished = false
+			}
+
+			switch r.Status {
+			case RunPending, RunPlanQueued, RunApplyQueued, RunPlanned,
+				RunCostEstimated, RunPolicyChecked, RunPolicyOverride:
+				if r.Actions != nil && (r.Actions.IsCancelable || r.Actions.IsDiscardable) {
+					pending = append(pending, r)
+				}
+			}
+		}
+
+		// Runs are listed from newest to oldest, so older runs
+		// can no longer be pending once a page only holds
+		// finished runs.
+		if finished || rl.Pagination == nil || rl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = rl.NextPage
+	}
+
+	var reason *string
+	if comment != "" {
+		reason = String(comment)
+	}
+
+	canceled := []string{}
+	for _, r := range pending {
+		if r.Actions.IsCancelable {
+			if err := s.client.Runs.Cancel(ctx, r.ID, RunCancelOptions{Comment: reason}); err != nil {
+				return canceled, fmt.Errorf("error canceling run %s: %v", r.ID, err)
+			}
+		} else {
+			if err := s.client.Runs.Discard(ctx, r.ID, RunDiscardOptions{Comment: reason}); err != nil {
+				return canceled, fmt.Errorf("error discarding run %s: %v", r.ID, err)
+			}
+		}
+		canceled = append(canceled, r.ID)
+	}
+
+	return canceled, nil
+}
+
+// CreateWithVariables creates a workspace and then creates the given
+// variables in it. The variables are validated before anything is created.
+// When creating a variable fails and rollback is true, the workspace is
+// deleted again and nothing is returned but the error. Otherwise the
+// workspace and the variables that were created are returned together with
+// the error.
+func (s *workspaces) CreateWithVariables(ctx context.Context, organization string, options WorkspaceCreateOptions, vars []VariableCreateOptions, rollback bool) (*Workspace, []*Variable, error) {
+	for _, o := range vars {
+		// The workspace is not known yet, but will be set when
+		// creating the variables.
+		o.Workspace = &Workspace{}
+		if err := o.valid(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	w, err := s.Create(ctx, organization, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var created []*Variable
+	for _, o := range vars {
+		o.Workspace = w
+
+		var v *Variable
+		v, err = s.client.Variables.Create(ctx, o)
+		if err != nil {
+			err = fmt.Errorf("error creating variable %q: %v", *o.Key, err)
+			break
+		}
+
+		created = append(created, v)
+	}
+
+	if err != nil {
+		if !rollback {
+			return w, created, err
+		}
+
+		if derr := s.Delete(ctx, organization, w.Name); derr != nil {
+			return w, created, fmt.Errorf("%v (rollback failed: %v)", err, derr)
+		}
+
+		return nil, nil, err
+	}
+
+	return w, created, nil
+}
+
+// SetAssessmentsEnabled enables or disables health assessments for each of
+// the given workspaces. The workspaces are updated concurrently. Failures do
+// not abort the other updates, but are returned together as a *BulkError
+// keyed by workspace ID.
+func (s *workspaces) SetAssessmentsEnabled(ctx context.Context, workspaceIDs []string, enabled bool) error {
+	for _, workspaceID := range workspaceIDs {
+		if !validStringID(&workspaceID) {
+			return errors.New("invalid value for workspace ID")
+		}
+	}
+
+	options := WorkspaceUpdateOptions{AssessmentsEnabled: Bool(enabled)}
+
+	return forEachWithOptions(ctx, workspaceIDs, options, func(ctx context.Context, workspaceID string) error {
+		_, err := s.updateByID(ctx, workspaceID, options)
+		return err
+	})
+}
+
+// AddTags adds the given tags to each of the given workspaces. The tag names
+// are validated before any workspace is updated. The workspaces are updated
+// concurrently. Failures do not abort the other updates, but are returned
+// together as a *BulkError keyed by workspace ID.
+func (s *workspaces) AddTags(ctx context.Context, workspaceIDs []string, tags []string) error {
+	return s.updateTags(ctx, "POST", workspaceIDs, tags)
+}
+
+// RemoveTags removes the given tags from each of the given workspaces. The
+// tag names are validated before any workspace is updated. The workspaces
+// are updated concurrently. Failures do not abort the other updates, but
+// are returned together as a *BulkError keyed by workspace ID. When
+// Config.ConfirmDestructive is enabled, ctx must be confirmed using
+// WithDestructiveConfirmed.
+func (s *workspaces) RemoveTags(ctx context.Context, workspaceIDs []string, tags []string) error {
+	if err := s.client.checkDestructive(ctx); err != nil {
+		return err
+	}
+	return s.updateTags(ctx, "DELETE", workspaceIDs, tags)
+}
+
+// updateTags adds or removes (depending on the method) the given tags to or
+// from each of the given workspaces.
+func (s *workspaces) updateTags(ctx context.Context, method string, workspaceIDs []string, tags []string) error {
+	for _, workspaceID := range workspaceIDs {
+		if !validStringID(&workspaceID) {
+			return errors.New("invalid value for workspace ID")
+		}
+	}
+	if len(tags) == 0 {
+		return errors.New("must provide at least one tag")
+	}
+
+	var ts []*Tag
+	for _, name := range tags {
+		if !validTagName(name) {
+			return fmt.Errorf("invalid tag name %q", name)
+		}
+		ts = append(ts, &Tag{Name: name})
+	}
+
+	return forEachWithOptions(ctx, workspaceIDs, tags, func(ctx context.Context, workspaceID string) error {
+		u := fmt.Sprintf("workspaces/%s/relationships/tags", url.PathEscape(workspaceID))
+		req, err := s.client.newRequest(method, u, ts)
+		if err != nil {
+			return err
+		}
+
+		return s.client.do(ctx, req, nil)
+	})
+}
+
+// PreflightResult represents the result of the preflight checks of a
+// workspace.
+type PreflightResult struct {
+	// Whether the workspace exists and is visible with the current token.
+	Exists bool
+
+	// Whether the current token is allowed to queue runs on the workspace.
+	CanQueueRun bool
+
+	// Whether the workspace is locked.
+	Locked bool
+
+	// The workspace, or nil if it does not exist.
+	Workspace *Workspace
+}
+
+// Ready reports whether all preflight checks passed, meaning that runs can
+// be queued on the workspace.
+func (r *PreflightResult) Ready() bool {
+	return r.Exists && r.CanQueueRun && !r.Locked
+}
+
+// Preflight checks if the given workspace exists, if the current token is
+// allowed to queue runs on it and if it is locked, all with a single call.
+// A workspace that does not exist (or is not visible with the current
+// token) is reported in the result and not as an error.
+func (s *workspaces) Preflight(ctx context.Context, workspaceID string) (*PreflightResult, error) {
+	w, err := s.readByID(ctx, workspaceID, workspaceReadOptions{})
+	if errors.Is(err, ErrResourceNotFound) {
+		return &PreflightResult{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PreflightResult{
+		Exists:    true,
+		Locked:    w.Locked,
+		Workspace: w,
+	}
+	if w.Permissions != nil {
+		result.CanQueueRun = w.Permissions.CanQueueRun
+	}
+
+	return result, nil
+}
+
+// EffectiveVariables returns the variables that are used by runs of the
+// given workspace: the variables of the workspace itself merged with the
+// variables of all variable sets applied to it, including global sets.
+// When multiple variables use the same key and category, the one with the
+// highest precedence is returned:
+//
+//   1. variables of priority variable sets
+//   2. variables of the workspace
+//   3. variables of variable sets applied to the workspace
+//   4. variables of global variable sets
+//
+// Variable sets with the same precedence are ordered by name. Variables of
+// the workspace have their Workspace set, while variables of a variable set
+// have their VariableSet set. Sensitive values are never returned by the
+// API. The result is sorted by category and key.
+func (s *workspaces) EffectiveVariables(ctx context.Context, workspaceID string) ([]*Variable, error) {
+	w, err := s.readByID(ctx, workspaceID, workspaceReadOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if w.Organization == nil {
+		return nil, fmt.Errorf("workspace %s does not have an organization", workspaceID)
+	}
+
+	// Get the variables of the workspace.
+	var workspaceVars []*Variable
+	options := VariableListOptions{
+		Organization: String(w.Organization.Name),
+		Workspace:    String(w.Name),
+	}
+	for {
+		vl, err := s.client.Variables.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range vl.Items {
+			v.Workspace = w
+			workspaceVars = append(workspaceVars, v)
+		}
+
+		if vl.Pagination == nil || vl.NextPage == 0 {
+			break
+		}
+		options.PageNumber = vl.NextPage
+	}
+
+	// Get the variable sets applied to the workspace.
+	var sets []*VariableSet
+	setOptions := VariableSetListOptions{Include: String("vars")}
+	for {
+		vsl, err := s.client.VariableSets.ListForWorkspace(ctx, workspaceID, setOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, vsl.Items...)
+
+		if vsl.Pagination == nil || vsl.NextPage == 0 {
+			break
+		}
+		setOptions.PageNumber = vsl.NextPage
+	}
+
+	return mergeVariables(workspaceVars, sets), nil
+}
+
+// mergeVariables merges the variables of a workspace with the variables of
+// the given variable sets, where the variable with the highest precedence
+// is returned for each key and category. The result is sorted by category
+// and key.
+func mergeVariables(workspaceVars []*Variable, sets []*VariableSet) []*Variable {
+	sets = append([]*VariableSet(nil), sets...)
+	SortVariableSetsByPrecedence(sets)
+
+	// Add the variables from the highest to the lowest precedence,
+	// skipping any variable that is already set.
+	seen := make(map[string]bool)
+	var result []*Variable
+
+	add := func(vars []*Variable, vs *VariableSet) {
+		for _, v := range vars {
+			k := string(v.Category) + "/" + v.Key
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+
+			if vs != nil {
+				v.VariableSet = vs
+			}
+			result = append(result, v)
+		}
+	}
+
+	workspaceAdded := false
+	for _, vs := range sets {
+		if !vs.Priority && !workspaceAdded {
+			add(workspaceVars, nil)
+			workspaceAdded = true
+		}
+		add(vs.Variables, vs)
+	}
+	if !workspaceAdded {
+		add(workspaceVars, nil)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Category != result[j].Category {
+			return result[i].Category < result[j].Category
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// WorkspaceSafeDeleteOptions represents the options for safely deleting a
+// workspace.
+type WorkspaceSafeDeleteOptions struct {
+	// Delete the workspace even if other workspaces consume its state.
+	Force bool
+}
+
+// RemoteStateConsumersError is returned by SafeDelete when other workspaces
+// consume the state of the workspace that is to be deleted.
+type RemoteStateConsumersError struct {
+	// The ID of the workspace that was not deleted.
+	WorkspaceID string
+
+	// The workspaces consuming the state of the workspace.
+	Consumers []*Workspace
+}
+
+// Error implements the error interface.
+func (e *RemoteStateConsumersError) Error() string {
+	names := make([]string, len(e.Consumers))
+	for i, w := range e.Consumers {
+		names[i] = w.Name
+	}
+	return fmt.Sprintf("workspace %s is used as remote state by: %s",
+		e.WorkspaceID, strings.Join(names, ", "))
+}
+
+// SafeDelete deletes a workspace by its ID. Unless forced, the workspace is
+// only deleted if no other workspaces are configured as remote state
+// consumers of it, and a *RemoteStateConsumersError listing the consumers
+// is returned otherwise. Workspaces that share their state globally with
+// the organization do not have explicit consumers, so for those
+// ErrWorkspaceGlobalRemoteState is returned unless forced.
+//
+// Like Delete, SafeDelete is not guarded by Config.ConfirmDestructive, even
+// when forced. That guard only applies to bulk helpers, where a single call
+// can affect many resources, while SafeDelete deletes one explicitly named
+// workspace.
+func (s *workspaces) SafeDelete(ctx context.Context, workspaceID string, options WorkspaceSafeDeleteOptions) error {
+	if !validStringID(&workspaceID) {
+		return errors.New("invalid value for workspace ID")
+	}
+
+	if !options.Force {
+		w, err := s.readByID(ctx, workspaceID, workspaceReadOptions{})
+		if err != nil {
+			return err
+		}
+		if w.GlobalRemoteState {
+			return ErrWorkspaceGlobalRemoteState
+		}
+
+		var consumers []*Workspace
+
+		listOptions := RemoteStateConsumersListOptions{}
+		for {
+			wl, err := s.RemoteStateConsumers(ctx, workspaceID, listOptions)
+			if err != nil {
+				return err
+			}
+
+			for _, w := range wl.Items {
+				if w.ID != workspaceID {
+					consumers = append(consumers, w)
+				}
+			}
+
+			if wl.Pagination == nil || wl.NextPage == 0 {
+				break
+			}
+			listOptions.PageNumber = wl.NextPage
+		}
+
+		if len(consumers) > 0 {
+			sort.Slice(consumers, func(i, j int) bool {
+				return consumers[i].Name < consumers[j].Name
+			})
+			return &RemoteStateConsumersError{WorkspaceID: workspaceID, Consumers: consumers}
+		}
+	}
+
+	u := fmt.Sprintf("workspaces/%s", url.PathEscape(workspaceID))
+	req, err := s.client.newRequest("DELETE", u, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
@@ -2,6 +2,11 @@ package tfe
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,6 +63,26 @@ func TestWorkspacesList(t *testing.T) {
 		assert.Equal(t, 1, wl.TotalCount)
 	})
 
+	t.Run("when including the current run", func(t *testing.T) {
+		rTest, _ := createRun(t, client, wTest1)
+
+		wl, err := client.Workspaces.List(ctx, orgTest.Name, WorkspaceListOptions{
+			Include: String("current_run"),
+		})
+		require.NoError(t, err)
+		require.Len(t, wl.Items, 2)
+
+		for _, w := range wl.Items {
+			if w.ID != wTest1.ID {
+				assert.Nil(t, w.CurrentRun)
+				continue
+			}
+			require.NotNil(t, w.CurrentRun)
+			assert.Equal(t, rTest.ID, w.CurrentRun.ID)
+			assert.NotEmpty(t, w.CurrentRun.Status)
+		}
+	})
+
 	t.Run("when searching an unknown workspace", func(t *testing.T) {
 		// Use a nonexisting workspace name as search attribute. The result
 		// should be successful, but return no results.
@@ -86,11 +111,14 @@ func TestWorkspacesCreate(t *testing.T) {
 
 	t.Run("with valid options", func(t *testing.T) {
 		options := WorkspaceCreateOptions{
-			Name:             String("foo"),
-			AutoApply:        Bool(true),
-			QueueAllRuns:     Bool(true),
-			TerraformVersion: String("0.11.0"),
-			WorkingDirectory: String("bar/"),
+			Name:                String("foo"),
+			AutoApply:           Bool(true),
+			AutoApplyRunTrigger: Bool(false),
+			GlobalRemoteState:   Bool(true),
+			Operations:          Bool(false),
+			QueueAllRuns:        Bool(true),
+			TerraformVersion:    String("0.11.0"),
+			WorkingDirectory:    String("bar/"),
 		}
 
 		w, err := client.Workspaces.Create(ctx, orgTest.Name, options)
@@ -107,6 +135,9 @@ func TestWorkspacesCreate(t *testing.T) {
 			assert.NotEmpty(t, item.ID)
 			assert.Equal(t, *options.Name, item.Name)
 			assert.Equal(t, *options.AutoApply, item.AutoApply)
+			assert.Equal(t, *options.AutoApplyRunTrigger, item.AutoApplyRunTrigger)
+			assert.Equal(t, *options.GlobalRemoteState, item.GlobalRemoteState)
+			assert.Equal(t, *options.Operations, item.Operations)
 			assert.Equal(t, *options.QueueAllRuns, item.QueueAllRuns)
 			assert.Equal(t, *options.TerraformVersion, item.TerraformVersion)
 			assert.Equal(t, *options.WorkingDirectory, item.WorkingDirectory)
@@ -119,6 +150,56 @@ func TestWorkspacesCreate(t *testing.T) {
 		assert.EqualError(t, err, "name is required")
 	})
 
+	t.Run("when options has an absolute working directory", func(t *testing.T) {
+		w, err := client.Workspaces.Create(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name:             String("foo"),
+			WorkingDirectory: String("/bar"),
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "working directory must be a relative path")
+	})
+
+	t.Run("with a source name and URL", func(t *testing.T) {
+		options := WorkspaceCreateOptions{
+			Name:       String(randomString(t)),
+			SourceName: String("my-platform"),
+			SourceURL:  String("https://example.com/workspaces/1"),
+		}
+
+		w, err := client.Workspaces.Create(ctx, orgTest.Name, options)
+		require.NoError(t, err)
+
+		refreshed, err := client.Workspaces.Read(ctx, orgTest.Name, *options.Name)
+		require.NoError(t, err)
+
+		for _, item := range []*Workspace{
+			w,
+			refreshed,
+		} {
+			assert.Equal(t, *options.SourceName, item.SourceName)
+			assert.Equal(t, *options.SourceURL, item.SourceURL)
+		}
+	})
+
+	t.Run("when options has a source name without a URL", func(t *testing.T) {
+		w, err := client.Workspaces.Create(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name:       String("foo"),
+			SourceName: String("my-platform"),
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "source URL is required when setting a source name")
+	})
+
+	t.Run("when options has an invalid source URL", func(t *testing.T) {
+		w, err := client.Workspaces.Create(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name:       String("foo"),
+			SourceName: String("my-platform"),
+			SourceURL:  String("example.com/workspaces/1"),
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "invalid value for source URL")
+	})
+
 	t.Run("when options has an invalid name", func(t *testing.T) {
 		w, err := client.Workspaces.Create(ctx, "foo", WorkspaceCreateOptions{
 			Name: String(badIdentifier),
@@ -227,11 +308,14 @@ func TestWorkspacesUpdate(t *testing.T) {
 
 	t.Run("with valid options", func(t *testing.T) {
 		options := WorkspaceUpdateOptions{
-			Name:             String(randomString(t)),
-			AutoApply:        Bool(false),
-			QueueAllRuns:     Bool(false),
-			TerraformVersion: String("0.11.1"),
-			WorkingDirectory: String("baz/"),
+			Name:                String(randomString(t)),
+			AutoApply:           Bool(false),
+			AutoApplyRunTrigger: Bool(true),
+			GlobalRemoteState:   Bool(true),
+			Operations:          Bool(false),
+			QueueAllRuns:        Bool(false),
+			TerraformVersion:    String("0.11.1"),
+			WorkingDirectory:    String("baz/"),
 		}
 
 		w, err := client.Workspaces.Update(ctx, orgTest.Name, wTest.Name, options)
@@ -247,12 +331,23 @@ func TestWorkspacesUpdate(t *testing.T) {
 		} {
 			assert.Equal(t, *options.Name, item.Name)
 			assert.Equal(t, *options.AutoApply, item.AutoApply)
+			assert.Equal(t, *options.AutoApplyRunTrigger, item.AutoApplyRunTrigger)
+			assert.Equal(t, *options.GlobalRemoteState, item.GlobalRemoteState)
+			assert.Equal(t, *options.Operations, item.Operations)
 			assert.Equal(t, *options.QueueAllRuns, item.QueueAllRuns)
 			assert.Equal(t, *options.TerraformVersion, item.TerraformVersion)
 			assert.Equal(t, *options.WorkingDirectory, item.WorkingDirectory)
 		}
 	})
 
+	t.Run("when options has a working directory outside the root", func(t *testing.T) {
+		w, err := client.Workspaces.Update(ctx, orgTest.Name, wTest.Name, WorkspaceUpdateOptions{
+			WorkingDirectory: String("../baz"),
+		})
+		assert.Nil(t, w)
+		assert.EqualError(t, err, "working directory must be a relative path")
+	})
+
 	t.Run("when an error is returned from the api", func(t *testing.T) {
 		w, err := client.Workspaces.Update(ctx, orgTest.Name, wTest.Name, WorkspaceUpdateOptions{
 			TerraformVersion: String("nonexisting"),
@@ -303,6 +398,71 @@ func TestWorkspacesDelete(t *testing.T) {
 	})
 }
 
+func TestWorkspacesSafeDelete(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+	wConsumer, _ := createWorkspace(t, client, orgTest)
+
+	err := client.Workspaces.AddRemoteStateConsumers(ctx, wTest.ID, WorkspaceAddRemoteStateConsumersOptions{
+		Workspaces: []*Workspace{wConsumer},
+	})
+	require.NoError(t, err)
+
+	t.Run("when the state is consumed by other workspaces", func(t *testing.T) {
+		err := client.Workspaces.SafeDelete(ctx, wTest.ID, WorkspaceSafeDeleteOptions{})
+
+		consumersErr, ok := err.(*RemoteStateConsumersError)
+		require.True(t, ok, "expected a *RemoteStateConsumersError, got: %v", err)
+		require.Len(t, consumersErr.Consumers, 1)
+		assert.Equal(t, wConsumer.ID, consumersErr.Consumers[0].ID)
+
+		// The workspace should still exist.
+		_, err = client.Workspaces.Read(ctx, orgTest.Name, wTest.Name)
+		assert.NoError(t, err)
+	})
+
+	t.Run("when forced", func(t *testing.T) {
+		err := client.Workspaces.SafeDelete(ctx, wTest.ID, WorkspaceSafeDeleteOptions{Force: true})
+		require.NoError(t, err)
+
+		// Try loading the workspace - it should fail.
+		_, err = client.Workspaces.Read(ctx, orgTest.Name, wTest.Name)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without remote state consumers", func(t *testing.T) {
+		err := client.Workspaces.SafeDelete(ctx, wConsumer.ID, WorkspaceSafeDeleteOptions{})
+		require.NoError(t, err)
+	})
+
+	t.Run("when the state is shared with the organization", func(t *testing.T) {
+		wGlobal, wGlobalCleanup := createWorkspace(t, client, orgTest)
+		defer wGlobalCleanup()
+
+		_, err := client.Workspaces.Update(ctx, orgTest.Name, wGlobal.Name, WorkspaceUpdateOptions{
+			GlobalRemoteState: Bool(true),
+		})
+		require.NoError(t, err)
+
+		err = client.Workspaces.SafeDelete(ctx, wGlobal.ID, WorkspaceSafeDeleteOptions{})
+		assert.Equal(t, ErrWorkspaceGlobalRemoteState, err)
+
+		// The workspace should still exist.
+		_, err = client.Workspaces.Read(ctx, orgTest.Name, wGlobal.Name)
+		assert.NoError(t, err)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		err := client.Workspaces.SafeDelete(ctx, badIdentifier, WorkspaceSafeDeleteOptions{})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
 func TestWorkspacesLock(t *testing.T) {
 	client := testClient(t)
 	ctx := context.Background()
@@ -478,3 +638,688 @@ func TestWorkspacesUnassignSSHKey(t *testing.T) {
 		assert.EqualError(t, err, "invalid value for workspace ID")
 	})
 }
+
+func TestWorkspacesOutputValue(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	_, svTestCleanup := createStateVersion(t, client, 0, wTest)
+	defer svTestCleanup()
+
+	t.Run("with an existing output", func(t *testing.T) {
+		svo, err := client.Workspaces.OutputValue(ctx, wTest.ID, "test_output")
+		require.NoError(t, err)
+		assert.NotEmpty(t, svo.ID)
+		assert.Equal(t, "test_output", svo.Name)
+		assert.Equal(t, "string", svo.Type)
+		assert.Equal(t, "foo", svo.Value)
+		assert.False(t, svo.Sensitive)
+	})
+
+	t.Run("with a sensitive output", func(t *testing.T) {
+		svo, err := client.Workspaces.OutputValue(ctx, wTest.ID, "test_secret")
+		require.NoError(t, err)
+		assert.Equal(t, "test_secret", svo.Name)
+		assert.True(t, svo.Sensitive)
+		assert.Nil(t, svo.Value)
+	})
+
+	t.Run("when the output does not exist", func(t *testing.T) {
+		svo, err := client.Workspaces.OutputValue(ctx, wTest.ID, "nonexisting")
+		assert.Nil(t, svo)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("without an output name", func(t *testing.T) {
+		svo, err := client.Workspaces.OutputValue(ctx, wTest.ID, "")
+		assert.Nil(t, svo)
+		assert.EqualError(t, err, "output name is required")
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		svo, err := client.Workspaces.OutputValue(ctx, badIdentifier, "test_output")
+		assert.Nil(t, svo)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesTeamAccessList(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	tmTest1, _ := createTeam(t, client, orgTest)
+	tmTest2, _ := createTeam(t, client, orgTest)
+
+	taTest1, _ := createTeamAccess(t, client, tmTest1, wTest, orgTest)
+	taTest2, _ := createTeamAccess(t, client, tmTest2, wTest, orgTest)
+
+	t.Run("with a valid workspace ID", func(t *testing.T) {
+		tas, err := client.Workspaces.TeamAccessList(ctx, wTest.ID)
+		require.NoError(t, err)
+		require.Len(t, tas, 2)
+
+		teams := make(map[string]*TeamAccess)
+		for _, ta := range tas {
+			teams[ta.ID] = ta
+		}
+
+		require.Contains(t, teams, taTest1.ID)
+		assert.Equal(t, taTest1.Access, teams[taTest1.ID].Access)
+		assert.Equal(t, tmTest1.Name, teams[taTest1.ID].Team.Name)
+
+		require.Contains(t, teams, taTest2.ID)
+		assert.Equal(t, taTest2.Access, teams[taTest2.ID].Access)
+		assert.Equal(t, tmTest2.Name, teams[taTest2.ID].Team.Name)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		tas, err := client.Workspaces.TeamAccessList(ctx, badIdentifier)
+		assert.Nil(t, tas)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesTeamAccessListIncludesTeams(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+		requests = append(requests, r.URL.RequestURI())
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Write([]byte(`{
+			"data": [
+				{"type": "team-workspaces", "id": "tws-1", "attributes": {"access": "read"},
+					"relationships": {"team": {"data": {"type": "teams", "id": "team-1"}}}},
+				{"type": "team-workspaces", "id": "tws-2", "attributes": {"access": "admin"},
+					"relationships": {"team": {"data": {"type": "teams", "id": "team-2"}}}}
+			],
+			"included": [
+				{"type": "teams", "id": "team-1", "attributes": {"name": "readers"}},
+				{"type": "teams", "id": "team-2", "attributes": {"name": "owners"}}
+			]
+		}`))
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	tas, err := client.Workspaces.TeamAccessList(context.Background(), "ws-123")
+	require.NoError(t, err)
+	require.Len(t, tas, 2)
+	assert.Equal(t, "readers", tas[0].Team.Name)
+	assert.Equal(t, "owners", tas[1].Team.Name)
+
+	// The teams are included, so they are not read separately.
+	assert.Equal(t, []string{
+		"/api/v2/team-workspaces?filter%5Bworkspace%5D%5Bid%5D=ws-123&include=team",
+	}, requests)
+}
+
+func TestWorkspacesCurrentRuns(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, _ := createWorkspace(t, client, orgTest)
+	wTest2, _ := createWorkspace(t, client, orgTest)
+
+	rTest, _ := createRun(t, client, wTest1)
+
+	t.Run("with valid workspace IDs", func(t *testing.T) {
+		runs, err := client.Workspaces.CurrentRuns(ctx, []string{wTest1.ID, wTest2.ID})
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		require.NotNil(t, runs[wTest1.ID])
+		assert.Equal(t, rTest.ID, runs[wTest1.ID].ID)
+		assert.NotEmpty(t, runs[wTest1.ID].Status)
+		assert.Nil(t, runs[wTest2.ID])
+	})
+
+	t.Run("when a workspace does not exist", func(t *testing.T) {
+		runs, err := client.Workspaces.CurrentRuns(ctx, []string{wTest1.ID, "ws-nonexisting"})
+		require.Len(t, runs, 2)
+		require.NotNil(t, runs[wTest1.ID])
+		assert.Nil(t, runs["ws-nonexisting"])
+
+		bulkErr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got %T", err)
+		assert.Equal(t, ErrResourceNotFound, bulkErr.Errors["ws-nonexisting"])
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		runs, err := client.Workspaces.CurrentRuns(ctx, []string{badIdentifier})
+		assert.Nil(t, runs)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesRemoteStateConsumers(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+	wConsumer1, _ := createWorkspace(t, client, orgTest)
+	wConsumer2, _ := createWorkspace(t, client, orgTest)
+
+	t.Run("when adding remote state consumers", func(t *testing.T) {
+		err := client.Workspaces.AddRemoteStateConsumers(ctx, wTest.ID, WorkspaceAddRemoteStateConsumersOptions{
+			Workspaces: []*Workspace{wConsumer1, wConsumer2},
+		})
+		require.NoError(t, err)
+
+		wl, err := client.Workspaces.RemoteStateConsumers(ctx, wTest.ID, RemoteStateConsumersListOptions{})
+		require.NoError(t, err)
+		assert.Len(t, wl.Items, 2)
+	})
+
+	t.Run("when removing remote state consumers", func(t *testing.T) {
+		err := client.Workspaces.RemoveRemoteStateConsumers(ctx, wTest.ID, WorkspaceRemoveRemoteStateConsumersOptions{
+			Workspaces: []*Workspace{wConsumer1},
+		})
+		require.NoError(t, err)
+
+		wl, err := client.Workspaces.RemoteStateConsumers(ctx, wTest.ID, RemoteStateConsumersListOptions{})
+		require.NoError(t, err)
+		require.Len(t, wl.Items, 1)
+		assert.Equal(t, wConsumer2.ID, wl.Items[0].ID)
+	})
+
+	t.Run("when replacing remote state consumers", func(t *testing.T) {
+		err := client.Workspaces.UpdateRemoteStateConsumers(ctx, wTest.ID, WorkspaceUpdateRemoteStateConsumersOptions{
+			Workspaces: []*Workspace{wConsumer1},
+		})
+		require.NoError(t, err)
+
+		wl, err := client.Workspaces.RemoteStateConsumers(ctx, wTest.ID, RemoteStateConsumersListOptions{})
+		require.NoError(t, err)
+		require.Len(t, wl.Items, 1)
+		assert.Equal(t, wConsumer1.ID, wl.Items[0].ID)
+	})
+
+	t.Run("when removing all remote state consumers", func(t *testing.T) {
+		err := client.Workspaces.UpdateRemoteStateConsumers(ctx, wTest.ID, WorkspaceUpdateRemoteStateConsumersOptions{
+			Workspaces: []*Workspace{},
+		})
+		require.NoError(t, err)
+
+		wl, err := client.Workspaces.RemoteStateConsumers(ctx, wTest.ID, RemoteStateConsumersListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, wl.Items)
+	})
+
+	t.Run("without workspaces", func(t *testing.T) {
+		err := client.Workspaces.AddRemoteStateConsumers(ctx, wTest.ID, WorkspaceAddRemoteStateConsumersOptions{})
+		assert.EqualError(t, err, "workspaces is required")
+	})
+
+	t.Run("with an empty list of workspaces", func(t *testing.T) {
+		err := client.Workspaces.RemoveRemoteStateConsumers(ctx, wTest.ID, WorkspaceRemoveRemoteStateConsumersOptions{
+			Workspaces: []*Workspace{},
+		})
+		assert.EqualError(t, err, "must provide at least one workspace")
+	})
+
+	t.Run("with an invalid consumer workspace ID", func(t *testing.T) {
+		err := client.Workspaces.AddRemoteStateConsumers(ctx, wTest.ID, WorkspaceAddRemoteStateConsumersOptions{
+			Workspaces: []*Workspace{{ID: badIdentifier}},
+		})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		wl, err := client.Workspaces.RemoteStateConsumers(ctx, badIdentifier, RemoteStateConsumersListOptions{})
+		assert.Nil(t, wl)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesCancelPendingRuns(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	// The first run will be planned and waits for confirmation, so
+	// it is discarded, while the runs created after it are pending
+	// and will be canceled.
+	rTest1, _ := createPlannedRun(t, client, wTest)
+	rTest2, _ := createRun(t, client, wTest)
+	rTest3, _ := createRun(t, client, wTest)
+
+	t.Run("when there are pending runs", func(t *testing.T) {
+		canceled, err := client.Workspaces.CancelPendingRuns(ctx, wTest.ID, "emergency stop")
+		require.NoError(t, err)
+
+		assert.Contains(t, canceled, rTest1.ID)
+		assert.Contains(t, canceled, rTest2.ID)
+		assert.Contains(t, canceled, rTest3.ID)
+	})
+
+	t.Run("when there are no pending runs", func(t *testing.T) {
+		canceled, err := client.Workspaces.CancelPendingRuns(ctx, wTest.ID, "")
+		require.NoError(t, err)
+		assert.Empty(t, canceled)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		canceled, err := client.Workspaces.CancelPendingRuns(ctx, badIdentifier, "")
+		assert.Nil(t, canceled)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesCancelPendingRunsStatuses(t *testing.T) {
+	tests := []struct {
+		status    RunStatus
+		actions   string
+		operation string
+	}{
+		{RunPending, `{"is-cancelable": true}`, "cancel"},
+		{RunPlanQueued, `{"is-cancelable": true}`, "cancel"},
+		{RunApplyQueued, `{"is-cancelable": true}`, "cancel"},
+		{RunPlanned, `{"is-confirmable": true, "is-discardable": true}`, "discard"},
+		{RunCostEstimated, `{"is-confirmable": true, "is-discardable": true}`, "discard"},
+		{RunPolicyChecked, `{"is-confirmable": true, "is-discardable": true}`, "discard"},
+		{RunPolicyOverride, `{"is-discardable": true}`, "discard"},
+		{RunPending, `{}`, ""},
+		{RunPlanning, `{"is-cancelable": true}`, ""},
+		{RunApplying, `{"is-cancelable": true}`, ""},
+		{RunPlannedAndFinished, `{}`, ""},
+	}
+
+	var items []string
+	expected := map[string]string{}
+	for i, tt := range tests {
+		id := fmt.Sprintf("run-%d", i)
+		items = append(items, fmt.Sprintf(
+			`{"type": "runs", "id": %q, "attributes": {"status": %q, "actions": %s}}`,
+			id, tt.status, tt.actions))
+		if tt.operation != "" {
+			expected[id] = tt.operation
+		}
+	}
+
+	var mu sync.Mutex
+	operations := map[string]string{}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "30")
+		if r.URL.Path == "/api/v2/" {
+			w.WriteHeader(204)
+			return
+		}
+
+		if r.URL.Path == "/api/v2/workspaces/ws-123/runs" {
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			fmt.Fprintf(w, `{"data": [%s]}`, strings.Join(items, ","))
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v2/runs/"), "/")
+		if r.Method != "POST" || len(parts) != 3 || parts[1] != "actions" {
+			w.WriteHeader(404)
+			return
+		}
+
+		mu.Lock()
+		operations[parts[0]] = parts[2]
+		mu.Unlock()
+
+		w.WriteHeader(202)
+	}))
+	defer ts.Close()
+
+	client, err := NewClient(&Config{
+		Address:    ts.URL,
+		Token:      "dummy-token",
+		HTTPClient: ts.Client(),
+	})
+	require.NoError(t, err)
+
+	canceled, err := client.Workspaces.CancelPendingRuns(context.Background(), "ws-123", "")
+	require.NoError(t, err)
+
+	for i, tt := range tests {
+		id := fmt.Sprintf("run-%d", i)
+		assert.Equal(t, expected[id], operations[id], "run with status %s", tt.status)
+	}
+	assert.Len(t, canceled, len(expected))
+	for _, id := range canceled {
+		assert.Contains(t, expected, id)
+	}
+}
+
+func TestWorkspacesCreateWithVariables(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	t.Run("with valid options", func(t *testing.T) {
+		w, vars, err := client.Workspaces.CreateWithVariables(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name: String(randomString(t)),
+		}, []VariableCreateOptions{
+			{Key: String("region"), Value: String("eu-west-1"), Category: Category(CategoryTerraform)},
+			{Key: String("TF_LOG"), Value: String("debug"), Category: Category(CategoryEnv)},
+		}, true)
+		require.NoError(t, err)
+		require.Len(t, vars, 2)
+
+		assert.Equal(t, "region", vars[0].Key)
+		assert.Equal(t, "TF_LOG", vars[1].Key)
+		for _, v := range vars {
+			assert.Equal(t, w.ID, v.Workspace.ID)
+		}
+	})
+
+	t.Run("with invalid variable options", func(t *testing.T) {
+		name := randomString(t)
+
+		w, vars, err := client.Workspaces.CreateWithVariables(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name: String(name),
+		}, []VariableCreateOptions{
+			{Key: String("region"), Category: Category(CategoryTerraform)},
+		}, true)
+		assert.Nil(t, w)
+		assert.Nil(t, vars)
+		assert.EqualError(t, err, "value is required")
+
+		// Make sure the workspace was never created.
+		_, err = client.Workspaces.Read(ctx, orgTest.Name, name)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("when creating a variable fails with rollback", func(t *testing.T) {
+		name := randomString(t)
+
+		w, vars, err := client.Workspaces.CreateWithVariables(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name: String(name),
+		}, []VariableCreateOptions{
+			{Key: String("region"), Value: String("eu-west-1"), Category: Category(CategoryTerraform)},
+			{Key: String("region"), Value: String("us-east-1"), Category: Category(CategoryTerraform)},
+		}, true)
+		assert.Nil(t, w)
+		assert.Nil(t, vars)
+		assert.Error(t, err)
+
+		_, err = client.Workspaces.Read(ctx, orgTest.Name, name)
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("when creating a variable fails without rollback", func(t *testing.T) {
+		name := randomString(t)
+
+		w, vars, err := client.Workspaces.CreateWithVariables(ctx, orgTest.Name, WorkspaceCreateOptions{
+			Name: String(name),
+		}, []VariableCreateOptions{
+			{Key: String("region"), Value: String("eu-west-1"), Category: Category(CategoryTerraform)},
+			{Key: String("region"), Value: String("us-east-1"), Category: Category(CategoryTerraform)},
+		}, false)
+		require.Error(t, err)
+		require.NotNil(t, w)
+		require.Len(t, vars, 1)
+		assert.Equal(t, "eu-west-1", vars[0].Value)
+
+		_, err = client.Workspaces.Read(ctx, orgTest.Name, name)
+		assert.NoError(t, err)
+	})
+
+	t.Run("with invalid organization", func(t *testing.T) {
+		w, vars, err := client.Workspaces.CreateWithVariables(ctx, badIdentifier, WorkspaceCreateOptions{
+			Name: String(randomString(t)),
+		}, nil, true)
+		assert.Nil(t, w)
+		assert.Nil(t, vars)
+		assert.EqualError(t, err, "invalid value for organization")
+	})
+}
+
+func TestWorkspacesSetAssessmentsEnabled(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, _ := createWorkspace(t, client, orgTest)
+	wTest2, _ := createWorkspace(t, client, orgTest)
+
+	t.Run("with valid workspace IDs", func(t *testing.T) {
+		err := client.Workspaces.SetAssessmentsEnabled(ctx, []string{wTest1.ID, wTest2.ID}, true)
+		require.NoError(t, err)
+
+		for _, w := range []*Workspace{wTest1, wTest2} {
+			refreshed, err := client.Workspaces.Read(ctx, orgTest.Name, w.Name)
+			require.NoError(t, err)
+			assert.True(t, refreshed.AssessmentsEnabled)
+		}
+	})
+
+	t.Run("when a workspace does not exist", func(t *testing.T) {
+		err := client.Workspaces.SetAssessmentsEnabled(ctx, []string{wTest1.ID, "nonexisting"}, false)
+
+		berr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got: %v", err)
+		assert.Len(t, berr.Errors, 1)
+		assert.Equal(t, ErrResourceNotFound, berr.Errors["nonexisting"])
+
+		refreshed, err := client.Workspaces.Read(ctx, orgTest.Name, wTest1.Name)
+		require.NoError(t, err)
+		assert.False(t, refreshed.AssessmentsEnabled)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		err := client.Workspaces.SetAssessmentsEnabled(ctx, []string{badIdentifier}, true)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesAddTags(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest1, _ := createWorkspace(t, client, orgTest)
+	wTest2, _ := createWorkspace(t, client, orgTest)
+
+	t.Run("with valid tags", func(t *testing.T) {
+		err := client.Workspaces.AddTags(ctx, []string{wTest1.ID, wTest2.ID}, []string{"team:infra", "prod"})
+		require.NoError(t, err)
+
+		for _, w := range []*Workspace{wTest1, wTest2} {
+			refreshed, err := client.Workspaces.Read(ctx, orgTest.Name, w.Name)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, []string{"team:infra", "prod"}, refreshed.TagNames)
+		}
+	})
+
+	t.Run("when removing a tag", func(t *testing.T) {
+		err := client.Workspaces.RemoveTags(ctx, []string{wTest1.ID, wTest2.ID}, []string{"prod"})
+		require.NoError(t, err)
+
+		for _, w := range []*Workspace{wTest1, wTest2} {
+			refreshed, err := client.Workspaces.Read(ctx, orgTest.Name, w.Name)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"team:infra"}, refreshed.TagNames)
+		}
+	})
+
+	t.Run("when a workspace does not exist", func(t *testing.T) {
+		err := client.Workspaces.AddTags(ctx, []string{wTest1.ID, "nonexisting"}, []string{"staging"})
+
+		berr, ok := err.(*BulkError)
+		require.True(t, ok, "expected a *BulkError, got: %v", err)
+		assert.Len(t, berr.Errors, 1)
+		assert.Equal(t, ErrResourceNotFound, berr.Errors["nonexisting"])
+	})
+
+	t.Run("without any tags", func(t *testing.T) {
+		err := client.Workspaces.AddTags(ctx, []string{wTest1.ID}, nil)
+		assert.EqualError(t, err, "must provide at least one tag")
+	})
+
+	t.Run("with an invalid tag name", func(t *testing.T) {
+		err := client.Workspaces.RemoveTags(ctx, []string{wTest1.ID}, []string{"prod", "not valid"})
+		assert.EqualError(t, err, `invalid tag name "not valid"`)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		err := client.Workspaces.AddTags(ctx, []string{badIdentifier}, []string{"prod"})
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesPreflight(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	wTest, wTestCleanup := createWorkspace(t, client, nil)
+	defer wTestCleanup()
+
+	t.Run("when the workspace is ready", func(t *testing.T) {
+		result, err := client.Workspaces.Preflight(ctx, wTest.ID)
+		require.NoError(t, err)
+		assert.True(t, result.Exists)
+		assert.True(t, result.CanQueueRun)
+		assert.False(t, result.Locked)
+		assert.True(t, result.Ready())
+		assert.Equal(t, wTest.ID, result.Workspace.ID)
+	})
+
+	t.Run("when the workspace is locked", func(t *testing.T) {
+		_, err := client.Workspaces.Lock(ctx, wTest.ID, WorkspaceLockOptions{})
+		require.NoError(t, err)
+		defer client.Workspaces.Unlock(ctx, wTest.ID)
+
+		result, err := client.Workspaces.Preflight(ctx, wTest.ID)
+		require.NoError(t, err)
+		assert.True(t, result.Exists)
+		assert.True(t, result.Locked)
+		assert.False(t, result.Ready())
+	})
+
+	t.Run("when the workspace does not exist", func(t *testing.T) {
+		result, err := client.Workspaces.Preflight(ctx, "nonexisting")
+		require.NoError(t, err)
+		assert.False(t, result.Exists)
+		assert.Nil(t, result.Workspace)
+		assert.False(t, result.Ready())
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		result, err := client.Workspaces.Preflight(ctx, badIdentifier)
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestWorkspacesEffectiveVariables(t *testing.T) {
+	client := testClient(t)
+	ctx := context.Background()
+
+	orgTest, orgTestCleanup := createOrganization(t, client)
+	defer orgTestCleanup()
+
+	wTest, _ := createWorkspace(t, client, orgTest)
+
+	_, err := client.Variables.Create(ctx, VariableCreateOptions{
+		Key:       String("region"),
+		Value:     String("eu-west-1"),
+		Category:  Category(CategoryTerraform),
+		Workspace: wTest,
+	})
+	require.NoError(t, err)
+
+	createVariableSet(t, client, orgTest, VariableSetCreateOptions{
+		Global: Bool(true),
+	})
+
+	t.Run("with an empty global variable set", func(t *testing.T) {
+		vars, err := client.Workspaces.EffectiveVariables(ctx, wTest.ID)
+		require.NoError(t, err)
+		require.Len(t, vars, 1)
+
+		assert.Equal(t, "region", vars[0].Key)
+		assert.Equal(t, "eu-west-1", vars[0].Value)
+		assert.Equal(t, wTest.ID, vars[0].Workspace.ID)
+		assert.Nil(t, vars[0].VariableSet)
+	})
+
+	t.Run("without a valid workspace ID", func(t *testing.T) {
+		vars, err := client.Workspaces.EffectiveVariables(ctx, badIdentifier)
+		assert.Nil(t, vars)
+		assert.EqualError(t, err, "invalid value for workspace ID")
+	})
+}
+
+func TestMergeVariables(t *testing.T) {
+	tfVar := func(key, value string) *Variable {
+		return &Variable{Key: key, Value: value, Category: CategoryTerraform}
+	}
+
+	workspaceVars := []*Variable{
+		tfVar("a", "workspace"),
+		tfVar("b", "workspace"),
+		{Key: "a", Value: "workspace-env", Category: CategoryEnv},
+	}
+
+	sets := []*VariableSet{
+		{Name: "global", Global: true, Variables: []*Variable{
+			tfVar("a", "global"), tfVar("c", "global"), tfVar("d", "global"),
+		}},
+		{Name: "scoped", Variables: []*Variable{
+			tfVar("c", "scoped"),
+		}},
+		{Name: "priority", Priority: true, Variables: []*Variable{
+			tfVar("b", "priority"),
+		}},
+	}
+
+	vars := mergeVariables(workspaceVars, sets)
+
+	var got []string
+	for _, v := range vars {
+		source := "workspace"
+		if v.VariableSet != nil {
+			source = v.VariableSet.Name
+		}
+		got = append(got, fmt.Sprintf("%s/%s=%s (%s)", v.Category, v.Key, v.Value, source))
+	}
+
+	assert.Equal(t, []string{
+		"env/a=workspace-env (workspace)",
+		"terraform/a=workspace (workspace)",
+		"terraform/b=priority (priority)",
+		"terraform/c=scoped (scoped)",
+		"terraform/d=global (global)",
+	}, got)
+
+	// The given variable sets are not reordered.
+	assert.Equal(t, "global", sets[0].Name)
+}